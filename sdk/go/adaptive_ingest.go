@@ -0,0 +1,131 @@
+package aionbd
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAdaptiveMin         = 1
+	defaultAdaptiveMax         = 8
+	defaultAdaptiveInterval    = time.Second
+	defaultAdaptiveLatencyRise = 0.2
+)
+
+// AdaptiveConcurrency lets ImportCollection upload batches concurrently and
+// tune the number of workers from server metrics.
+//
+// The control loop starts at Max workers and reads /metrics every Interval.
+// A sample counts as pressure when HTTPRequestDurationUsAvg grew by more than
+// LatencyRise (a fraction) since the previous sample, or when
+// RateLimitRejectionsTotal increased. Pressure halves the concurrency, never
+// below Min; a calm sample adds one worker, never above Max. Samples that fail
+// to load leave the concurrency unchanged. OnChange is called with every new
+// value.
+type AdaptiveConcurrency struct {
+	Min         int
+	Max         int
+	Interval    time.Duration
+	LatencyRise float64
+	OnChange    func(concurrency int)
+}
+
+func (config AdaptiveConcurrency) withDefaults() AdaptiveConcurrency {
+	if config.Min <= 0 {
+		config.Min = defaultAdaptiveMin
+	}
+	if config.Max <= 0 {
+		config.Max = defaultAdaptiveMax
+	}
+	if config.Max < config.Min {
+		config.Max = config.Min
+	}
+	if config.Interval <= 0 {
+		config.Interval = defaultAdaptiveInterval
+	}
+	if config.LatencyRise <= 0 {
+		config.LatencyRise = defaultAdaptiveLatencyRise
+	}
+	return config
+}
+
+// adaptiveLimiter bounds in-flight work by a limit that can change while
+// workers wait.
+type adaptiveLimiter struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	limit    int
+	inFlight int
+}
+
+func newAdaptiveLimiter(limit int) *adaptiveLimiter {
+	limiter := &adaptiveLimiter{limit: limit}
+	limiter.cond = sync.NewCond(&limiter.mu)
+	return limiter
+}
+
+func (limiter *adaptiveLimiter) acquire() {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	for limiter.inFlight >= limiter.limit {
+		limiter.cond.Wait()
+	}
+	limiter.inFlight++
+}
+
+func (limiter *adaptiveLimiter) release() {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	limiter.inFlight--
+	limiter.cond.Broadcast()
+}
+
+func (limiter *adaptiveLimiter) setLimit(limit int) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	limiter.limit = limit
+	limiter.cond.Broadcast()
+}
+
+// runAdaptiveController adjusts limiter until ctx is done.
+func (c *Client) runAdaptiveController(ctx context.Context, config AdaptiveConcurrency, limiter *adaptiveLimiter) {
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	concurrency := config.Max
+	var previous *MetricsResponse
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		metrics, err := c.Metrics(ctx)
+		if err != nil {
+			continue
+		}
+		if previous != nil {
+			next := concurrency + 1
+			if isIngestPressure(*previous, metrics, config.LatencyRise) {
+				next = concurrency / 2
+			}
+			next = max(config.Min, min(config.Max, next))
+			if next != concurrency {
+				concurrency = next
+				limiter.setLimit(concurrency)
+				if config.OnChange != nil {
+					config.OnChange(concurrency)
+				}
+			}
+		}
+		previous = &metrics
+	}
+}
+
+func isIngestPressure(previous MetricsResponse, current MetricsResponse, latencyRise float64) bool {
+	if current.RateLimitRejectionsTotal > previous.RateLimitRejectionsTotal {
+		return true
+	}
+	return current.HTTPRequestDurationUsAvg > previous.HTTPRequestDurationUsAvg*(1+latencyRise)
+}
@@ -0,0 +1,74 @@
+package aionbd
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveIngestBacksOffOnRisingLatency(t *testing.T) {
+	t.Parallel()
+
+	var samples atomic.Int64
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/metrics" {
+			// Average latency doubles on every sample.
+			average := 100 * math.Pow(2, float64(samples.Add(1)))
+			writeJSON(t, writer, map[string]any{"http_request_duration_us_avg": average})
+			return
+		}
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := peak.Load()
+			if current <= seen || peak.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		writeJSON(t, writer, map[string]any{"created": 1, "updated": 0, "results": []any{}})
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var changes []int
+	client := NewClient(server.URL, &ClientOptions{Ingest: IngestConfig{Adaptive: &AdaptiveConcurrency{
+		Min:      1,
+		Max:      8,
+		Interval: 10 * time.Millisecond,
+		OnChange: func(concurrency int) {
+			mu.Lock()
+			changes = append(changes, concurrency)
+			mu.Unlock()
+		},
+	}}})
+	lines := strings.Repeat(`{"id":1,"values":[1,2]}`+"\n", 200)
+	stats, err := client.ImportCollection(context.Background(), "demo", strings.NewReader(lines), 1)
+	if err != nil {
+		t.Fatalf("ImportCollection returned error: %v", err)
+	}
+	if stats.Created != 200 || stats.Batches != 200 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if peak.Load() < 2 {
+		t.Fatalf("expected concurrent uploads before backing off, peak was %d", peak.Load())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(changes) == 0 || changes[0] != 4 || changes[len(changes)-1] != 1 {
+		t.Fatalf("expected concurrency to halve down to 1, got %v", changes)
+	}
+	for index := 1; index < len(changes); index++ {
+		if changes[index] >= changes[index-1] {
+			t.Fatalf("expected concurrency to keep decreasing, got %v", changes)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// withAutoCreate runs upsert and, when ClientOptions.AutoCreateCollections is
+// set and the upsert failed because collection does not exist, creates it
+// with the given dimension and retries once. Existing collections are never
+// touched: a 404 for a collection that does exist is returned unchanged. The
+// lookup and creation are sent with the upsert's call options, so per-call
+// credentials and tenant headers apply to them too.
+func (c *Client) withAutoCreate(ctx context.Context, collection string, dimension int, opts []CallOption, upsert func() error) error {
+	err := upsert()
+	if !c.autoCreateCollections || dimension <= 0 || !isStatus(err, http.StatusNotFound) {
+		return err
+	}
+	created, createErr := c.createMissingCollection(ctx, collection, dimension, helperCallOptions(opts))
+	if createErr != nil {
+		return createErr
+	}
+	if !created {
+		return err
+	}
+	return upsert()
+}
+
+func (c *Client) createMissingCollection(ctx context.Context, collection string, dimension int, opts []CallOption) (bool, error) {
+	_, err := c.GetCollection(ctx, collection, opts...)
+	if err == nil {
+		return false, nil
+	}
+	if !isStatus(err, http.StatusNotFound) {
+		return false, err
+	}
+	_, err = c.CreateCollection(ctx, collection, dimension, c.autoCreateStrictFinite, opts...)
+	if err != nil && !isStatus(err, http.StatusConflict) {
+		return false, err
+	}
+	c.logger.Info("aionbd: created missing collection on upsert", "collection", collection, "dimension", dimension)
+	return true, nil
+}
+
+func isStatus(err error, status int) bool {
+	var requestErr *Error
+	return errors.As(err, &requestErr) && requestErr.Status == status
+}
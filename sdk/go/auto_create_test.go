@@ -0,0 +1,85 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestAutoCreateCollectionsCreatesWithInferredDimension(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		created map[string]any
+		puts    int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if request.Header.Get("X-Tenant") != "a" {
+			t.Errorf("%s %s sent without the caller's tenant header", request.Method, request.URL.Path)
+		}
+		if key := request.Header.Get("Idempotency-Key"); (key != "") != (request.Method == http.MethodPut) {
+			t.Errorf("%s %s: unexpected Idempotency-Key %q", request.Method, request.URL.Path, key)
+		}
+		switch {
+		case request.Method == http.MethodPut && request.URL.Path == "/collections/fresh/points/1":
+			puts++
+			if created == nil {
+				writer.WriteHeader(http.StatusNotFound)
+				writeJSON(t, writer, map[string]any{"error": "collection 'fresh' not found"})
+				return
+			}
+			writeJSON(t, writer, map[string]any{"id": 1, "created": true})
+		case request.Method == http.MethodGet && request.URL.Path == "/collections/fresh":
+			writer.WriteHeader(http.StatusNotFound)
+			writeJSON(t, writer, map[string]any{"error": "collection 'fresh' not found"})
+		case request.Method == http.MethodPost && request.URL.Path == "/collections":
+			if err := json.NewDecoder(request.Body).Decode(&created); err != nil {
+				t.Errorf("decode create body: %v", err)
+			}
+			writeJSON(t, writer, map[string]any{"name": "fresh", "dimension": created["dimension"], "strict_finite": created["strict_finite"], "point_count": 0})
+		default:
+			t.Errorf("unexpected request: %s %s", request.Method, request.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{AutoCreateCollections: true, AutoCreateStrictFinite: true})
+	response, err := client.UpsertPoint(context.Background(), "fresh", 1, []float32{0.1, 0.2, 0.3}, nil,
+		WithHeader("X-Tenant", "a"), WithHeader("Idempotency-Key", "upsert-1"))
+	if err != nil {
+		t.Fatalf("upsert with auto-create failed: %v", err)
+	}
+	if !response.Created {
+		t.Fatalf("unexpected upsert response: %#v", response)
+	}
+	if created["dimension"] != float64(3) || created["strict_finite"] != true {
+		t.Fatalf("unexpected create body: %#v", created)
+	}
+	if puts != 2 {
+		t.Fatalf("expected the upsert to be retried once, got %d attempts", puts)
+	}
+}
+
+func TestAutoCreateIsOptIn(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPut {
+			t.Errorf("unexpected request without auto-create: %s %s", request.Method, request.URL.Path)
+		}
+		writer.WriteHeader(http.StatusNotFound)
+		writeJSON(t, writer, map[string]any{"error": "collection 'fresh' not found"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	if _, err := client.UpsertPoint(context.Background(), "fresh", 1, []float32{0.1}, nil); !isStatus(err, http.StatusNotFound) {
+		t.Fatalf("expected 404 without auto-create, got: %v", err)
+	}
+}
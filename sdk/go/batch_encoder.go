@@ -0,0 +1,195 @@
+package aionbd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// upsertBatchPoints returns the points of a batch upsert body, the shape
+// upsertPointsBatch sends, so encodeBody can skip reflection for it.
+func upsertBatchPoints(body any) ([]UpsertPointsBatchItem, bool) {
+	fields, ok := body.(map[string]any)
+	if !ok || len(fields) != 1 {
+		return nil, false
+	}
+	points, ok := fields["points"].([]UpsertPointsBatchItem)
+	return points, ok
+}
+
+var batchEncoderBuffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// errNonFinite makes the encoder fall back to json.Marshal, which reports
+// NaN and infinities with its usual error.
+var errNonFinite = &json.UnsupportedValueError{Str: "non-finite number"}
+
+// encodeUpsertBatchBody writes {"points":[...]} byte for byte as json.Marshal
+// would, formatting into a pooled buffer without reflection. Payload values
+// other than strings, numbers, booleans and null still go through
+// encoding/json.
+func encodeUpsertBatchBody(points []UpsertPointsBatchItem) ([]byte, error) {
+	if points == nil {
+		return []byte(`{"points":null}`), nil
+	}
+	buffer := batchEncoderBuffers.Get().(*bytes.Buffer)
+	defer batchEncoderBuffers.Put(buffer)
+	buffer.Reset()
+
+	var keys []string
+	buffer.WriteString(`{"points":[`)
+	for index, point := range points {
+		if index > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.WriteString(`{"id":`)
+		buffer.Write(strconv.AppendUint(buffer.AvailableBuffer(), point.ID, 10))
+		buffer.WriteString(`,"values":`)
+		if point.Values == nil {
+			buffer.WriteString("null")
+		} else {
+			buffer.WriteByte('[')
+			for valueIndex, value := range point.Values {
+				if valueIndex > 0 {
+					buffer.WriteByte(',')
+				}
+				encoded, err := appendJSONFloat(buffer.AvailableBuffer(), float64(value), 32)
+				if err != nil {
+					return json.Marshal(map[string]any{"points": points})
+				}
+				buffer.Write(encoded)
+			}
+			buffer.WriteByte(']')
+		}
+		if len(point.Payload) > 0 {
+			buffer.WriteString(`,"payload":`)
+			keys = keys[:0]
+			for key := range point.Payload {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			buffer.WriteByte('{')
+			for keyIndex, key := range keys {
+				if keyIndex > 0 {
+					buffer.WriteByte(',')
+				}
+				encoded := appendJSONString(buffer.AvailableBuffer(), key)
+				encoded = append(encoded, ':')
+				encoded, err := appendPayloadValue(encoded, point.Payload[key])
+				if err == errNonFinite {
+					return json.Marshal(map[string]any{"points": points})
+				}
+				if err != nil {
+					return nil, err
+				}
+				buffer.Write(encoded)
+			}
+			buffer.WriteByte('}')
+		}
+		buffer.WriteByte('}')
+	}
+	buffer.WriteString("]}")
+	return bytes.Clone(buffer.Bytes()), nil
+}
+
+func appendPayloadValue(dst []byte, value any) ([]byte, error) {
+	switch value := value.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case string:
+		return appendJSONString(dst, value), nil
+	case bool:
+		return strconv.AppendBool(dst, value), nil
+	case float64:
+		return appendJSONFloat(dst, value, 64)
+	case int:
+		return strconv.AppendInt(dst, int64(value), 10), nil
+	case int64:
+		return strconv.AppendInt(dst, value, 10), nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, encoded...), nil
+}
+
+// appendJSONFloat mirrors encoding/json's float formatting: shortest
+// representation, exponent form outside [1e-6, 1e21), and no leading zero in
+// a negative two-digit exponent.
+func appendJSONFloat(dst []byte, value float64, bits int) ([]byte, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return dst, errNonFinite
+	}
+	abs := math.Abs(value)
+	format := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, value, format, -1, bits)
+	if format == 'e' {
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, nil
+}
+
+// appendJSONString quotes s the way json.Marshal does, including its HTML
+// escaping. Invalid UTF-8 is left to encoding/json, whose replacement
+// differs between its implementations.
+func appendJSONString(dst []byte, s string) []byte {
+	if !utf8.ValidString(s) {
+		encoded, _ := json.Marshal(s)
+		return append(dst, encoded...)
+	}
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
@@ -0,0 +1,96 @@
+package aionbd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func encoderTestPoints(random *rand.Rand, count int, dimension int) []UpsertPointsBatchItem {
+	points := make([]UpsertPointsBatchItem, count)
+	for index := range points {
+		values := make([]float32, dimension)
+		for component := range values {
+			values[component] = (random.Float32()*2 - 1) * float32(math.Pow(10, float64(random.Intn(60)-30)))
+		}
+		points[index] = UpsertPointsBatchItem{ID: random.Uint64(), Values: values}
+		if index%2 == 0 {
+			points[index].Payload = PointPayload{
+				"label":  "a<b & c>\t\"q\"\\ \b\f\x01\x7f \u2028 \xff é",
+				"rank":   index,
+				"big":    int64(-1) << 62,
+				"score":  random.Float64() * math.Pow(10, float64(random.Intn(60)-30)),
+				"ok":     index%4 == 0,
+				"none":   nil,
+				"tags":   []any{"x", 1.5},
+				"nested": map[string]any{"k": "<v>"},
+			}
+		}
+	}
+	return points
+}
+
+func TestEncodeUpsertBatchBodyMatchesJSONMarshal(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewSource(11))
+	cases := [][]UpsertPointsBatchItem{
+		nil,
+		{},
+		{{ID: 0, Values: nil}, {ID: math.MaxUint64, Values: []float32{}, Payload: PointPayload{}}},
+		{{ID: 1, Values: []float32{0, float32(math.Copysign(0, -1)), 1e-6, 9.99e-7, 1e21, 1e20, math.MaxFloat32, math.SmallestNonzeroFloat32, -3.25}}},
+		encoderTestPoints(random, 50, 33),
+	}
+	for index, points := range cases {
+		want, err := json.Marshal(map[string]any{"points": points})
+		if err != nil {
+			t.Fatalf("case %d: json.Marshal returned error: %v", index, err)
+		}
+		got, err := encodeUpsertBatchBody(points)
+		if err != nil {
+			t.Fatalf("case %d: encodeUpsertBatchBody returned error: %v", index, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("case %d: output differs\n got: %s\nwant: %s", index, got, want)
+		}
+	}
+
+	for _, point := range []UpsertPointsBatchItem{
+		{ID: 1, Values: []float32{float32(math.NaN())}},
+		{ID: 2, Values: []float32{1}, Payload: PointPayload{"score": math.Inf(1)}},
+	} {
+		if _, err := encodeUpsertBatchBody([]UpsertPointsBatchItem{point}); err == nil {
+			t.Fatalf("expected an error for non-finite point %d", point.ID)
+		}
+	}
+}
+
+func BenchmarkEncodeUpsertBatchBody(b *testing.B) {
+	random := rand.New(rand.NewSource(13))
+	points := make([]UpsertPointsBatchItem, 500)
+	for index := range points {
+		values := make([]float32, 384)
+		for component := range values {
+			values[component] = random.Float32()*2 - 1
+		}
+		points[index] = UpsertPointsBatchItem{ID: uint64(index), Values: values, Payload: PointPayload{"rank": index, "source": "bench"}}
+	}
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := json.Marshal(map[string]any{"points": points}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("encodeUpsertBatchBody", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := encodeUpsertBatchBody(points); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
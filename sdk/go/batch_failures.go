@@ -0,0 +1,17 @@
+package aionbd
+
+import "slices"
+
+// failedResults keeps only the results that report an error. Servers that
+// ignore ?results=failures return every result, so FailuresOnly filters
+// locally too; created and updated entries are dropped either way.
+func failedResults(results []UpsertPointResponse) []UpsertPointResponse {
+	results = slices.DeleteFunc(results, func(result UpsertPointResponse) bool {
+		return result.Error == ""
+	})
+	if len(results) == 0 {
+		return nil
+	}
+	// Copy so the full results array can be collected.
+	return slices.Clone(results)
+}
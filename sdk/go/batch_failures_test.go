@@ -0,0 +1,43 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpsertPointsBatchFailuresOnlyKeepsFailedResults(t *testing.T) {
+	t.Parallel()
+
+	var capturedQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		capturedQuery = request.URL.RawQuery
+		// Answer like a server that ignores the filter.
+		writeJSON(t, writer, map[string]any{"created": 1, "updated": 1, "results": []map[string]any{
+			{"id": 1, "created": true},
+			{"id": 2, "created": false, "error": "dimension mismatch"},
+			{"id": 3, "created": false},
+		}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	response, err := client.UpsertPointsBatchWithOptions(context.Background(), "demo", []UpsertPointsBatchItem{
+		{ID: 1, Values: []float32{1}},
+		{ID: 2, Values: []float32{1}},
+		{ID: 3, Values: []float32{1}},
+	}, &UpsertPointsBatchOptions{FailuresOnly: true})
+	if err != nil {
+		t.Fatalf("batch upsert failed: %v", err)
+	}
+	if capturedQuery != "results=failures" {
+		t.Fatalf("unexpected query: %s", capturedQuery)
+	}
+	if len(response.Results) != 1 || response.Results[0].ID != 2 || response.Results[0].Error != "dimension mismatch" {
+		t.Fatalf("expected only the failed result, got %#v", response.Results)
+	}
+	if response.Created != 1 || response.Updated != 1 {
+		t.Fatalf("unexpected counts: %#v", response)
+	}
+}
@@ -0,0 +1,20 @@
+package aionbd
+
+import "sort"
+
+// BatchItemsFromMap builds batch items from vectors keyed by point ID, in
+// ascending ID order so the encoded request is deterministic. Each item gets
+// the payload with the same ID, or nil when payloads has none.
+func BatchItemsFromMap(m map[uint64][]float32, payloads map[uint64]PointPayload) []UpsertPointsBatchItem {
+	ids := make([]uint64, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(left, right int) bool { return ids[left] < ids[right] })
+
+	items := make([]UpsertPointsBatchItem, len(ids))
+	for index, id := range ids {
+		items[index] = UpsertPointsBatchItem{ID: id, Values: m[id], Payload: payloads[id]}
+	}
+	return items
+}
@@ -0,0 +1,37 @@
+package aionbd
+
+import "testing"
+
+func TestBatchItemsFromMapOrdersByIDAndAttachesPayloads(t *testing.T) {
+	t.Parallel()
+
+	vectors := map[uint64][]float32{
+		42: {0.4, 0.2},
+		7:  {0.7, 0.1},
+		19: {0.1, 0.9},
+	}
+	payloads := map[uint64]PointPayload{
+		7:  {"label": "seven"},
+		42: {"label": "forty-two"},
+		99: {"label": "orphan"},
+	}
+
+	items := BatchItemsFromMap(vectors, payloads)
+	if len(items) != 3 {
+		t.Fatalf("unexpected item count: %d", len(items))
+	}
+	for index, id := range []uint64{7, 19, 42} {
+		if items[index].ID != id {
+			t.Fatalf("expected id %d at position %d, got %d", id, index, items[index].ID)
+		}
+		if items[index].Values[0] != vectors[id][0] {
+			t.Fatalf("unexpected values for id %d: %#v", id, items[index].Values)
+		}
+	}
+	if items[0].Payload["label"] != "seven" || items[2].Payload["label"] != "forty-two" {
+		t.Fatalf("payloads attached to wrong items: %#v", items)
+	}
+	if items[1].Payload != nil {
+		t.Fatalf("expected nil payload for id 19, got: %#v", items[1].Payload)
+	}
+}
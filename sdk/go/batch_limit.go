@@ -0,0 +1,89 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrBatchTooLarge   = errors.New("batch exceeds MaxBatchItems")
+	ErrPayloadTooLarge = errors.New("payload too large for the server; send smaller batches, set MaxBatchItems, or enable AutoChunkBatches")
+)
+
+// payloadSplitFloor is the batch size below which a 413 is no longer split.
+const payloadSplitFloor = 1
+
+func (c *Client) checkBatchSize(items int) error {
+	if c.maxBatchItems <= 0 || items <= c.maxBatchItems || c.autoChunkBatches {
+		return nil
+	}
+	return fmt.Errorf("%w: %d items, limit %d (enable AutoChunkBatches to split it)", ErrBatchTooLarge, items, c.maxBatchItems)
+}
+
+func (c *Client) shouldChunkBatch(items int) bool {
+	return c.maxBatchItems > 0 && items > c.maxBatchItems && c.autoChunkBatches
+}
+
+// upsertPointsBatchChunked sends the chunks in order and stops at the first
+// failure; chunks already sent stay written. An Idempotency-Key is suffixed
+// with -p<n> per chunk.
+func (c *Client) upsertPointsBatchChunked(ctx context.Context, collection string, points []UpsertPointsBatchItem, options *UpsertPointsBatchOptions, opts []CallOption) (UpsertPointsBatchResponse, error) {
+	ctx = withServerThrottle(ctx)
+	var merged UpsertPointsBatchResponse
+	for part, start := 0, 0; start < len(points); part, start = part+1, start+c.maxBatchItems {
+		end := min(start+c.maxBatchItems, len(points))
+		partOpts := withSubIdempotencyKey(opts, fmt.Sprintf("p%d", part))
+		response, err := c.upsertPointsBatchSplitting(ctx, collection, points[start:end], options, partOpts)
+		mergeUpsertResponses(&merged, response)
+		if err != nil {
+			return merged, err
+		}
+	}
+	return merged, nil
+}
+
+// upsertPointsBatchSplitting retries a batch the server rejected with 413 as
+// two halves, recursively, while AutoChunkBatches is on and the batch is above
+// payloadSplitFloor. Splits counts every split made. An Idempotency-Key is
+// suffixed with -s0 and -s1 for the halves.
+func (c *Client) upsertPointsBatchSplitting(ctx context.Context, collection string, points []UpsertPointsBatchItem, options *UpsertPointsBatchOptions, opts []CallOption) (UpsertPointsBatchResponse, error) {
+	response, err := c.upsertPointsBatch(ctx, collection, points, options, opts)
+	if !c.autoChunkBatches || !errors.Is(err, ErrPayloadTooLarge) || len(points) <= payloadSplitFloor {
+		return response, err
+	}
+
+	merged := UpsertPointsBatchResponse{Splits: 1}
+	middle := len(points) / 2
+	for index, half := range [][]UpsertPointsBatchItem{points[:middle], points[middle:]} {
+		halfOpts := withSubIdempotencyKey(opts, fmt.Sprintf("s%d", index))
+		response, err := c.upsertPointsBatchSplitting(ctx, collection, half, options, halfOpts)
+		mergeUpsertResponses(&merged, response)
+		if err != nil {
+			return merged, err
+		}
+	}
+	return merged, nil
+}
+
+func mergeUpsertResponses(merged *UpsertPointsBatchResponse, response UpsertPointsBatchResponse) {
+	merged.Created += response.Created
+	merged.Updated += response.Updated
+	merged.Splits += response.Splits
+	merged.Results = append(merged.Results, response.Results...)
+}
+
+func (c *Client) searchTopKBatchChunked(ctx context.Context, collection string, queries [][]float32, options *SearchTopKOptions, opts []CallOption) (SearchTopKBatchResponse, error) {
+	ctx = withServerThrottle(ctx)
+	var merged SearchTopKBatchResponse
+	for start := 0; start < len(queries); start += c.maxBatchItems {
+		end := min(start+c.maxBatchItems, len(queries))
+		response, err := c.searchTopKBatch(ctx, collection, queries[start:end], options, opts)
+		if err != nil {
+			return SearchTopKBatchResponse{}, err
+		}
+		merged.Metric = response.Metric
+		merged.Results = append(merged.Results, response.Results...)
+	}
+	return merged, nil
+}
@@ -0,0 +1,144 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMaxBatchItemsRejectsOversizedBatchesWithoutChunking(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		writeJSON(t, writer, map[string]any{})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{MaxBatchItems: 2})
+	points := []UpsertPointsBatchItem{{ID: 1, Values: []float32{1}}, {ID: 2, Values: []float32{2}}, {ID: 3, Values: []float32{3}}}
+	if _, err := client.UpsertPointsBatch(context.Background(), "demo", points); !errors.Is(err, ErrBatchTooLarge) {
+		t.Fatalf("expected ErrBatchTooLarge from upsert, got %v", err)
+	}
+	queries := [][]float32{{1}, {2}, {3}}
+	if _, err := client.SearchCollectionTopKBatch(context.Background(), "demo", queries, nil); !errors.Is(err, ErrBatchTooLarge) {
+		t.Fatalf("expected ErrBatchTooLarge from search, got %v", err)
+	}
+	if got := requests.Load(); got != 0 {
+		t.Fatalf("expected no requests, got %d", got)
+	}
+
+	if _, err := client.UpsertPointsBatch(context.Background(), "demo", points[:2]); err != nil {
+		t.Fatalf("batch at the limit should pass: %v", err)
+	}
+}
+
+func TestMaxBatchItemsAutoChunksBatches(t *testing.T) {
+	t.Parallel()
+
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body struct {
+			Points  []UpsertPointsBatchItem `json:"points"`
+			Queries [][]float32             `json:"queries"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		switch request.URL.Path {
+		case "/collections/demo/points":
+			sizes = append(sizes, len(body.Points))
+			results := make([]map[string]any, 0, len(body.Points))
+			for _, point := range body.Points {
+				results = append(results, map[string]any{"id": point.ID, "created": true})
+			}
+			writeJSON(t, writer, map[string]any{"created": len(body.Points), "updated": 0, "results": results})
+		case "/collections/demo/search/topk/batch":
+			sizes = append(sizes, len(body.Queries))
+			results := make([]map[string]any, 0, len(body.Queries))
+			for _, query := range body.Queries {
+				results = append(results, map[string]any{"mode": "exact", "hits": []map[string]any{{"id": int(query[0]), "value": 1.0}}})
+			}
+			writeJSON(t, writer, map[string]any{"metric": "dot", "results": results})
+		default:
+			t.Errorf("unexpected path %s", request.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{MaxBatchItems: 2, AutoChunkBatches: true})
+	points := []UpsertPointsBatchItem{{ID: 1, Values: []float32{1}}, {ID: 2, Values: []float32{2}}, {ID: 3, Values: []float32{3}}}
+	upserted, err := client.UpsertPointsBatch(context.Background(), "demo", points)
+	if err != nil {
+		t.Fatalf("UpsertPointsBatch returned error: %v", err)
+	}
+	if upserted.Created != 3 || len(upserted.Results) != 3 || upserted.Results[2].ID != 3 {
+		t.Fatalf("unexpected merged upsert response: %+v", upserted)
+	}
+
+	searched, err := client.SearchCollectionTopKBatch(context.Background(), "demo", [][]float32{{1}, {2}, {3}, {4}, {5}}, nil)
+	if err != nil {
+		t.Fatalf("SearchCollectionTopKBatch returned error: %v", err)
+	}
+	if searched.Metric != MetricDot || len(searched.Results) != 5 {
+		t.Fatalf("unexpected merged search response: %+v", searched)
+	}
+	for index, item := range searched.Results {
+		if item.Hits[0].ID != uint64(index+1) {
+			t.Fatalf("result %d out of order: %+v", index, item)
+		}
+	}
+
+	want := []int{2, 1, 2, 2, 1}
+	if len(sizes) != len(want) {
+		t.Fatalf("expected request sizes %v, got %v", want, sizes)
+	}
+	for index := range want {
+		if sizes[index] != want[index] {
+			t.Fatalf("expected request sizes %v, got %v", want, sizes)
+		}
+	}
+}
+
+func TestPayloadTooLargeSplitsBatchesWhenChunkingIsOn(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body struct {
+			Points []UpsertPointsBatchItem `json:"points"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		if len(body.Points) > 2 {
+			http.Error(writer, "payload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		writeJSON(t, writer, map[string]any{"created": len(body.Points), "updated": 0, "results": []any{}})
+	}))
+	defer server.Close()
+
+	points := make([]UpsertPointsBatchItem, 5)
+	for index := range points {
+		points[index] = UpsertPointsBatchItem{ID: uint64(index), Values: []float32{1}}
+	}
+
+	_, err := NewClient(server.URL, nil).UpsertPointsBatch(context.Background(), "demo", points)
+	if !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("expected ErrPayloadTooLarge, got %v", err)
+	}
+
+	client := NewClient(server.URL, &ClientOptions{AutoChunkBatches: true})
+	response, err := client.UpsertPointsBatch(context.Background(), "demo", points)
+	if err != nil {
+		t.Fatalf("UpsertPointsBatch returned error: %v", err)
+	}
+	if response.Created != 5 || response.Splits != 2 {
+		t.Fatalf("expected 5 created after 2 splits, got %+v", response)
+	}
+}
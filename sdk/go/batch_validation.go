@@ -0,0 +1,28 @@
+package aionbd
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func duplicatePointIDs(points []UpsertPointsBatchItem) []uint64 {
+	seen := make(map[uint64]int, len(points))
+	var duplicates []uint64
+	for _, point := range points {
+		seen[point.ID]++
+		if seen[point.ID] == 2 {
+			duplicates = append(duplicates, point.ID)
+		}
+	}
+	sort.Slice(duplicates, func(left, right int) bool { return duplicates[left] < duplicates[right] })
+	return duplicates
+}
+
+func joinPointIDs(ids []uint64) string {
+	parts := make([]string, len(ids))
+	for index, id := range ids {
+		parts[index] = strconv.FormatUint(id, 10)
+	}
+	return strings.Join(parts, ", ")
+}
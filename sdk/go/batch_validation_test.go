@@ -0,0 +1,23 @@
+package aionbd
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestUpsertPointsBatchRejectsDuplicateIDs(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("http://unit.test", &ClientOptions{RejectDuplicateIDs: true})
+	_, err := client.UpsertPointsBatch(context.Background(), "demo", []UpsertPointsBatchItem{
+		{ID: 9, Values: []float32{1}},
+		{ID: 4, Values: []float32{1}},
+		{ID: 9, Values: []float32{2}},
+		{ID: 4, Values: []float32{2}},
+		{ID: 1, Values: []float32{2}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "duplicate point ids in batch: 4, 9") {
+		t.Fatalf("expected duplicate id error naming 4 and 9, got: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package aionbd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+const redactedValue = "***"
+
+// vectorKeys are the request fields that carry vectors, either a single one
+// ("values", "query") or a list of them ("queries").
+var vectorKeys = map[string]bool{"values": true, "query": true, "queries": true, "vector": true}
+
+// bodyLogger logs redacted copies of request bodies. It is nil unless
+// ClientOptions.LogBodies is set, so disabled clients pay one nil check.
+type bodyLogger struct {
+	logger *slog.Logger
+	redact map[string]bool
+}
+
+func newBodyLogger(opts ClientOptions, logger *slog.Logger) *bodyLogger {
+	if !opts.LogBodies {
+		return nil
+	}
+	redact := make(map[string]bool, len(opts.RedactPayloadKeys))
+	for _, key := range opts.RedactPayloadKeys {
+		redact[key] = true
+	}
+	return &bodyLogger{logger: logger, redact: redact}
+}
+
+func (l *bodyLogger) log(ctx context.Context, method, path string, encoded []byte) {
+	if l == nil || !l.logger.Enabled(ctx, slog.LevelInfo) {
+		return
+	}
+	l.logger.LogAttrs(ctx, slog.LevelInfo, "aionbd: request body",
+		slog.String("method", method),
+		slog.String("path", pathWithoutQuery(path)),
+		slog.String("body", l.redacted(encoded)),
+	)
+}
+
+func (l *bodyLogger) redacted(encoded []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var body any
+	if err := decoder.Decode(&body); err != nil {
+		return fmt.Sprintf("[%d bytes, not JSON]", len(encoded))
+	}
+	redacted, err := json.Marshal(l.redactValue(body))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes]", len(encoded))
+	}
+	return string(redacted)
+}
+
+func (l *bodyLogger) redactValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, field := range typed {
+			switch {
+			case l.redact[key]:
+				typed[key] = redactedValue
+			case vectorKeys[key]:
+				typed[key] = redactVectors(field)
+			default:
+				typed[key] = l.redactValue(field)
+			}
+		}
+	case []any:
+		for index, item := range typed {
+			typed[index] = l.redactValue(item)
+		}
+	}
+	return value
+}
+
+// redactVectors replaces a numeric array with its length, recursing into
+// arrays of vectors. Anything else is left as is.
+func redactVectors(value any) any {
+	items, ok := value.([]any)
+	if !ok {
+		return value
+	}
+	numeric := true
+	for index, item := range items {
+		if _, isNumber := item.(json.Number); isNumber {
+			continue
+		}
+		numeric = false
+		items[index] = redactVectors(item)
+	}
+	if numeric {
+		return fmt.Sprintf("[%d floats]", len(items))
+	}
+	return items
+}
@@ -0,0 +1,78 @@
+package aionbd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogBodiesRedactsVectorsAndListedKeys(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writeJSON(t, writer, map[string]any{"created": 1, "updated": 0})
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(server.URL, &ClientOptions{
+		Logger:            slog.New(slog.NewJSONHandler(&logs, nil)),
+		LogBodies:         true,
+		RedactPayloadKeys: []string{"email"},
+	})
+	_, err := client.UpsertPointsBatch(context.Background(), "demo", []UpsertPointsBatchItem{
+		{ID: 1, Values: []float32{0.25, 0.5, 0.75}, Payload: PointPayload{"email": "ada@example.com", "team": "search"}},
+	})
+	if err != nil {
+		t.Fatalf("UpsertPointsBatch failed: %v", err)
+	}
+
+	var record struct {
+		Msg    string `json:"msg"`
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		Body   string `json:"body"`
+	}
+	if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("expected one JSON log record, got %q: %v", logs.String(), err)
+	}
+	if record.Method != http.MethodPost || record.Path != "/collections/demo/points" {
+		t.Fatalf("unexpected log record: %+v", record)
+	}
+	for _, want := range []string{`"values":"[3 floats]"`, `"email":"***"`, `"team":"search"`} {
+		if !strings.Contains(record.Body, want) {
+			t.Fatalf("expected %s in logged body %s", want, record.Body)
+		}
+	}
+	for _, leak := range []string{"0.25", "ada@example.com"} {
+		if strings.Contains(logs.String(), leak) {
+			t.Fatalf("log leaked %q: %s", leak, logs.String())
+		}
+	}
+}
+
+func TestLogBodiesRedactsBatchQueries(t *testing.T) {
+	t.Parallel()
+
+	logger := newBodyLogger(ClientOptions{LogBodies: true}, slog.Default())
+	got := logger.redacted([]byte(`{"queries":[[1,2],[3,4]],"limit":5}`))
+	if got != `{"limit":5,"queries":["[2 floats]","[2 floats]"]}` {
+		t.Fatalf("unexpected redacted body %s", got)
+	}
+}
+
+func TestBodyLoggingDisabledDoesNotAllocate(t *testing.T) {
+	client := NewClient("http://127.0.0.1:1", nil)
+	body := []byte(`{"values":[1,2,3]}`)
+	allocs := testing.AllocsPerRun(100, func() {
+		client.bodyLog.log(context.Background(), http.MethodPut, "/collections/demo/points/1", body)
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations with LogBodies disabled, got %.1f", allocs)
+	}
+}
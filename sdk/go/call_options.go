@@ -0,0 +1,102 @@
+package aionbd
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// CallOption adjusts a single API call without changing the client.
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	header          http.Header
+	rawCapture      *json.RawMessage
+	responseHeaders *http.Header
+	serverTiming    *ServerTiming
+	noCache         bool
+	node            string
+}
+
+func newCallConfig(opts []CallOption) *callConfig {
+	call := &callConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(call)
+		}
+	}
+	return call
+}
+
+// WithRawCapture stores a copy of the successful response body in target in
+// addition to decoding it into the typed response.
+func WithRawCapture(target *json.RawMessage) CallOption {
+	return func(call *callConfig) {
+		call.rawCapture = target
+	}
+}
+
+// WithResponseHeaders stores a copy of the response headers of a successful
+// call in target, e.g. to read a server version or request ID. Like
+// WithRawCapture, it bypasses the search and point caches.
+func WithResponseHeaders(target *http.Header) CallOption {
+	return func(call *callConfig) {
+		call.responseHeaders = target
+	}
+}
+
+// WithNoCache bypasses ClientOptions.SearchCache and PointCache for this call;
+// the response is not stored either.
+func WithNoCache() CallOption {
+	return func(call *callConfig) {
+		call.noCache = true
+	}
+}
+
+// WithHeader sets a request header for this call only, overriding client
+// default and context headers with the same name.
+func WithHeader(key string, value string) CallOption {
+	return withRequestHeader(key, value)
+}
+
+func withRequestHeader(key string, value string) CallOption {
+	return func(call *callConfig) {
+		if call.header == nil {
+			call.header = make(http.Header)
+		}
+		call.header.Set(key, value)
+	}
+}
+
+// helperCallOptions adapts the options of a call for a request the client
+// makes on its behalf, such as creating a missing collection: headers that
+// identify the caller are kept, while the Idempotency-Key and conditional
+// headers, which belong to the original request, and response captures are
+// dropped.
+func helperCallOptions(opts []CallOption) []CallOption {
+	return append(opts[:len(opts):len(opts)], func(call *callConfig) {
+		call.header.Del(idempotencyKeyHeader)
+		call.header.Del("If-None-Match")
+		call.rawCapture = nil
+		call.responseHeaders = nil
+		call.serverTiming = nil
+	})
+}
+
+// capturesResponse reports whether the call needs an actual response, so it
+// cannot be answered from a cache or a shared in-flight search.
+func (call *callConfig) capturesResponse() bool {
+	return call.rawCapture != nil || call.responseHeaders != nil || call.serverTiming != nil
+}
+
+func (call *callConfig) captureHeaders(header http.Header) {
+	if call.responseHeaders != nil {
+		*call.responseHeaders = header.Clone()
+	}
+	call.captureServerTiming(header)
+}
+
+func (call *callConfig) capture(payload []byte) {
+	if call.rawCapture != nil {
+		*call.rawCapture = append(json.RawMessage(nil), payload...)
+	}
+}
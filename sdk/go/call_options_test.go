@@ -0,0 +1,55 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithRawCaptureKeepsServerBytes(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"metric":"dot","mode":"exact","hits":[{"id":4,"value":0.75,"extra":"kept"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var raw json.RawMessage
+	client := NewClient(server.URL, nil)
+	response, err := client.SearchCollectionTopK(context.Background(), "demo", []float32{1}, nil, WithRawCapture(&raw))
+	if err != nil {
+		t.Fatalf("search top-k failed: %v", err)
+	}
+	if string(raw) != body {
+		t.Fatalf("unexpected raw body: %s", raw)
+	}
+	if len(response.Hits) != 1 || response.Hits[0].ID != 4 {
+		t.Fatalf("typed response not decoded: %#v", response)
+	}
+}
+
+func TestWithResponseHeadersCapturesServerHeaders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("X-Server-Version", "0.9.1")
+		writer.Header().Set("X-Request-ID", "req-42")
+		writeJSON(t, writer, map[string]any{"id": 3, "values": []float32{1}, "payload": map[string]any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{PointCache: &PointCacheConfig{MaxEntries: 8}})
+	for range 2 {
+		var header http.Header
+		if _, err := client.GetPoint(context.Background(), "demo", 3, WithResponseHeaders(&header)); err != nil {
+			t.Fatalf("GetPoint failed: %v", err)
+		}
+		if header.Get("X-Server-Version") != "0.9.1" || header.Get("X-Request-ID") != "req-42" {
+			t.Fatalf("expected the server headers, got %v", header)
+		}
+	}
+}
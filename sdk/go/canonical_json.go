@@ -0,0 +1,24 @@
+package aionbd
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// canonicalJSON encodes body with every object's keys sorted, including the
+// fields of structs such as UpsertPointsBatchItem (encoding/json only sorts
+// map keys). Numbers are carried through as their original literals, so the
+// output differs from json.Marshal in key order only.
+func canonicalJSON(body any) ([]byte, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
@@ -0,0 +1,57 @@
+package aionbd
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalJSONIsStableAndOnlyReordersKeys(t *testing.T) {
+	t.Parallel()
+
+	body := map[string]any{
+		"points": []UpsertPointsBatchItem{{
+			ID:      18446744073709551615,
+			Values:  []float32{0.1, 2.5},
+			Payload: PointPayload{"zeta": 1, "alpha": map[string]any{"y": true, "b": "<tag>"}},
+		}},
+	}
+
+	first, err := canonicalJSON(body)
+	if err != nil {
+		t.Fatalf("canonical encode failed: %v", err)
+	}
+	second, err := canonicalJSON(body)
+	if err != nil {
+		t.Fatalf("canonical encode failed: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Fatalf("canonical output is not stable:\n%s\n%s", first, second)
+	}
+
+	standard, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("standard encode failed: %v", err)
+	}
+	if bytes.Equal(first, standard) {
+		t.Fatal("expected struct fields to be reordered")
+	}
+	if !bytes.Contains(first, []byte(`{"id":18446744073709551615,"payload":`)) {
+		t.Fatalf("expected sorted struct fields and exact numbers, got %s", first)
+	}
+	if len(first) != len(standard) || !reflect.DeepEqual(decodeGeneric(t, first), decodeGeneric(t, standard)) {
+		t.Fatalf("canonical output differs beyond ordering:\n%s\n%s", first, standard)
+	}
+}
+
+func decodeGeneric(t *testing.T, payload []byte) any {
+	t.Helper()
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return generic
+}
@@ -0,0 +1,63 @@
+package aionbd
+
+import "sync"
+
+type capability string
+
+const (
+	capabilityExcludeIDs     capability = "exclude_ids"
+	capabilityIfNoneMatch    capability = "if_none_match"
+	capabilityHeadPoint      capability = "head_point"
+	capabilityPointsBatchGet capability = "points_batch_get"
+	capabilityZstdRequests   capability = "zstd_requests"
+	capabilityReturnPrevious capability = "return_previous"
+)
+
+type capabilityState int
+
+const (
+	capabilityUnknown capabilityState = iota
+	capabilitySupported
+	capabilityUnsupported
+)
+
+// capabilityCache remembers which optional server features were observed to
+// work or to be missing, so later calls can skip probes and fallbacks. It is
+// shared by every goroutine using the client, so all access goes through the
+// lock.
+type capabilityCache struct {
+	mu     sync.RWMutex
+	states map[capability]capabilityState
+}
+
+func newCapabilityCache() *capabilityCache {
+	return &capabilityCache{states: make(map[capability]capabilityState)}
+}
+
+func (cache *capabilityCache) supported(feature capability) bool {
+	return cache.state(feature) != capabilityUnsupported
+}
+
+func (cache *capabilityCache) confirmed(feature capability) bool {
+	return cache.state(feature) == capabilitySupported
+}
+
+func (cache *capabilityCache) state(feature capability) capabilityState {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+	return cache.states[feature]
+}
+
+func (cache *capabilityCache) markSupported(feature capability) {
+	cache.set(feature, capabilitySupported)
+}
+
+func (cache *capabilityCache) markUnsupported(feature capability) {
+	cache.set(feature, capabilityUnsupported)
+}
+
+func (cache *capabilityCache) set(feature capability, state capabilityState) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.states[feature] = state
+}
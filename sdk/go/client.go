@@ -1,15 +1,14 @@
 package aionbd
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const pointPathFormat = "/collections/%s/points/%d"
@@ -23,6 +22,9 @@ type Error struct {
 }
 
 func (e *Error) Error() string {
+	if e.Status > 0 && e.Err != nil {
+		return fmt.Sprintf("HTTP %d on %s %s: %v: %s", e.Status, e.Method, e.Path, e.Err, e.Body)
+	}
 	if e.Status > 0 {
 		return fmt.Sprintf("HTTP %d on %s %s: %s", e.Status, e.Method, e.Path, e.Body)
 	}
@@ -37,11 +39,49 @@ func (e *Error) Unwrap() error {
 }
 
 type Client struct {
+	configErr     error
+	logger        *slog.Logger
 	baseURL       string
+	pool          *nodePool
 	httpClient    *http.Client
 	apiKey        string
 	bearerToken   string
 	defaultHeader map[string]string
+	multiHeader   http.Header
+	onHTTPTrace   func(HTTPTraceTimings)
+	capabilities  *capabilityCache
+	signer        func(*http.Request, []byte) error
+	canonicalJSON bool
+	compression   CompressionAlgorithm
+	defaultMetric Metric
+	defaultMode   SearchMode
+	searchCache   *searchCache
+	coalescer     *searchCoalescer
+	pointCache    *pointCache
+	recentWrites  *recentWrites
+	throttle      *serverThrottle
+	bodyLog       *bodyLogger
+	metadata      *metadataCache
+	ingest        IngestConfig
+	idGenerator   func([]float32, PointPayload) uint64
+
+	rejectDuplicateIDs bool
+	operationTimeout   time.Duration
+	rateLimiter        *tokenBucket
+	retryPolicy        *RetryPolicy
+	onRetry            func(int, RetryInfo)
+	maxBatchItems      int
+	autoChunkBatches   bool
+	simpleRequests     bool
+
+	includePayloadByDefault *bool
+	autoCreateCollections   bool
+	autoCreateStrictFinite  bool
+	allowNonJSONContentType bool
+	adaptiveExactThreshold  int
+
+	onClockSkew        func(time.Duration)
+	clockSkewThreshold time.Duration
 }
 
 func NewClient(baseURL string, options *ClientOptions) *Client {
@@ -55,13 +95,45 @@ func NewClient(baseURL string, options *ClientOptions) *Client {
 		opts = *options
 	}
 
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	var configErr error
+	socketPath, isUnix := unixSocketPath(baseURL)
+	if isUnix {
+		baseURL = unixSocketBaseURL
+		if socketPath == "" {
+			configErr = fmt.Errorf("unix socket base URL must include a socket path")
+		} else if opts.HTTPClient != nil {
+			configErr = fmt.Errorf("unix socket base URL requires the SDK-built transport, not a custom HTTPClient")
+		}
+	}
+
+	if err := validateCompression(opts.Compression); err != nil && configErr == nil {
+		configErr = err
+	}
+	if opts.DefaultMetric != "" {
+		if err := validateMetric(opts.DefaultMetric); err != nil && configErr == nil {
+			configErr = fmt.Errorf("DefaultMetric: %w", err)
+		}
+	}
+
 	httpClient := opts.HTTPClient
 	if httpClient == nil {
 		timeout := opts.Timeout
 		if timeout <= 0 {
 			timeout = DefaultTimeout
 		}
-		httpClient = &http.Client{Timeout: timeout}
+		transport, err := newTransport(opts, socketPath)
+		if err != nil && configErr == nil {
+			configErr = err
+		}
+		httpClient = &http.Client{Timeout: timeout, Transport: transport}
+		if opts.InsecureSkipVerify {
+			logger.Warn("aionbd: TLS certificate verification is disabled; do not use InsecureSkipVerify in production", "base_url", baseURL)
+		}
 	}
 
 	headers := make(map[string]string, len(opts.Headers))
@@ -69,142 +141,360 @@ func NewClient(baseURL string, options *ClientOptions) *Client {
 		headers[key] = value
 	}
 
-	return &Client{
+	client := &Client{
+		configErr:     configErr,
+		logger:        logger,
 		baseURL:       baseURL,
 		httpClient:    httpClient,
 		apiKey:        opts.APIKey,
 		bearerToken:   opts.BearerToken,
 		defaultHeader: headers,
-	}
+		multiHeader:   opts.MultiHeaders.Clone(),
+		capabilities:  newCapabilityCache(),
+		signer:        opts.Signer,
+		canonicalJSON: opts.CanonicalJSON,
+		compression:   opts.Compression,
+		defaultMetric: opts.DefaultMetric,
+		defaultMode:   opts.DefaultMode,
+		searchCache:   newSearchCache(opts.SearchCache),
+		coalescer:     newSearchCoalescer(opts.CoalesceSearches),
+		pointCache:    newPointCache(opts.PointCache),
+		recentWrites:  newRecentWrites(),
+		throttle:      &serverThrottle{},
+		bodyLog:       newBodyLogger(opts, logger),
+		metadata:      newMetadataCache(opts.MetadataCacheTTL),
+		ingest:        opts.Ingest,
+		idGenerator:   opts.IDGenerator,
+
+		rejectDuplicateIDs: opts.RejectDuplicateIDs,
+		operationTimeout:   opts.OperationTimeout,
+		rateLimiter:        newTokenBucket(opts.RateLimit),
+		retryPolicy:        opts.RetryPolicy,
+		onRetry:            opts.OnRetry,
+		maxBatchItems:      opts.MaxBatchItems,
+		autoChunkBatches:   opts.AutoChunkBatches,
+
+		includePayloadByDefault: opts.IncludePayloadByDefault,
+		autoCreateCollections:   opts.AutoCreateCollections,
+		autoCreateStrictFinite:  opts.AutoCreateStrictFinite,
+		allowNonJSONContentType: opts.AllowNonJSONContentType,
+		adaptiveExactThreshold:  opts.AdaptiveExactThreshold,
+
+		onClockSkew:        opts.OnClockSkew,
+		clockSkewThreshold: opts.ClockSkewThreshold,
+	}
+	if opts.EnableHTTPTrace {
+		client.onHTTPTrace = opts.OnHTTPTrace
+	}
+	if opts.SimpleRequests {
+		client.simpleRequests = true
+		client.compression = CompressionNone
+	}
+	return client
 }
 
-func (c *Client) Live(ctx context.Context) (LiveResponse, error) {
+// ConfigError reports a problem found while building the client, such as an
+// unreadable certificate file. Every request fails with it until fixed.
+func (c *Client) ConfigError() error {
+	return c.configErr
+}
+
+func (c *Client) Live(ctx context.Context, opts ...CallOption) (LiveResponse, error) {
 	var response LiveResponse
-	err := c.requestJSON(ctx, http.MethodGet, "/live", nil, &response)
+	err := c.requestJSON(ctx, http.MethodGet, "/live", nil, &response, opts...)
 	return response, err
 }
 
-func (c *Client) Ready(ctx context.Context) (ReadyResponse, error) {
+func (c *Client) Ready(ctx context.Context, opts ...CallOption) (ReadyResponse, error) {
 	var response ReadyResponse
-	err := c.requestJSON(ctx, http.MethodGet, "/ready", nil, &response)
+	err := c.requestJSON(ctx, http.MethodGet, "/ready", nil, &response, opts...)
 	return response, err
 }
 
-func (c *Client) Health(ctx context.Context) (ReadyResponse, error) {
-	return c.Ready(ctx)
+func (c *Client) Health(ctx context.Context, opts ...CallOption) (ReadyResponse, error) {
+	return c.Ready(ctx, opts...)
 }
 
-func (c *Client) Metrics(ctx context.Context) (MetricsResponse, error) {
+func (c *Client) Metrics(ctx context.Context, opts ...CallOption) (MetricsResponse, error) {
 	var response MetricsResponse
-	err := c.requestJSON(ctx, http.MethodGet, "/metrics", nil, &response)
+	err := c.requestJSON(ctx, http.MethodGet, "/metrics", nil, &response, opts...)
 	return response, err
 }
 
-func (c *Client) MetricsPrometheus(ctx context.Context) (string, error) {
-	return c.requestRaw(ctx, http.MethodGet, "/metrics/prometheus", nil)
+func (c *Client) MetricsPrometheus(ctx context.Context, opts ...CallOption) (string, error) {
+	return c.requestRaw(ctx, http.MethodGet, "/metrics/prometheus", nil, opts...)
+}
+
+func (c *Client) Distance(ctx context.Context, left []float32, right []float32, metric Metric, opts ...CallOption) (DistanceResponse, error) {
+	return c.DistanceWithOptions(ctx, left, right, metric, nil, opts...)
 }
 
-func (c *Client) Distance(ctx context.Context, left []float32, right []float32, metric Metric) (DistanceResponse, error) {
+func (c *Client) DistanceWithOptions(ctx context.Context, left []float32, right []float32, metric Metric, options *DistanceOptions, opts ...CallOption) (DistanceResponse, error) {
 	body := map[string]any{
 		"left":   left,
 		"right":  right,
-		"metric": withMetricDefault(metric),
+		"metric": c.withMetricDefault(metric),
+	}
+	path := "/distance"
+	if options != nil && options.EchoInputs {
+		path += "?echo=true"
 	}
 	var response DistanceResponse
-	err := c.requestJSON(ctx, http.MethodPost, "/distance", body, &response)
+	err := c.requestJSON(ctx, http.MethodPost, path, body, &response, opts...)
 	return response, err
 }
 
-func (c *Client) CreateCollection(ctx context.Context, name string, dimension int, strictFinite bool) (CollectionResponse, error) {
+func (c *Client) CreateCollection(ctx context.Context, name string, dimension int, strictFinite bool, opts ...CallOption) (CollectionResponse, error) {
 	body := map[string]any{
 		"name":          name,
 		"dimension":     dimension,
 		"strict_finite": strictFinite,
 	}
+	c.invalidateMetadata(ctx, name, opts)
 	var response CollectionResponse
-	err := c.requestJSON(ctx, http.MethodPost, "/collections", body, &response)
+	err := c.requestJSON(ctx, http.MethodPost, "/collections", body, &response, opts...)
 	return response, err
 }
 
-func (c *Client) ListCollections(ctx context.Context) (ListCollectionsResponse, error) {
+func (c *Client) ListCollections(ctx context.Context, opts ...CallOption) (ListCollectionsResponse, error) {
 	var response ListCollectionsResponse
-	err := c.requestJSON(ctx, http.MethodGet, "/collections", nil, &response)
+	err := c.requestJSON(ctx, http.MethodGet, "/collections", nil, &response, opts...)
 	return response, err
 }
 
-func (c *Client) GetCollection(ctx context.Context, name string) (CollectionResponse, error) {
-	path := fmt.Sprintf("/collections/%s", url.PathEscape(strings.TrimSpace(name)))
+func (c *Client) GetCollection(ctx context.Context, name string, opts ...CallOption) (CollectionResponse, error) {
+	path := fmt.Sprintf("/collections/%s", collectionPathSegment(name))
 	var response CollectionResponse
-	err := c.requestJSON(ctx, http.MethodGet, path, nil, &response)
+	err := c.requestJSON(ctx, http.MethodGet, path, nil, &response, opts...)
+	if err == nil && response.DefaultMetric == "" {
+		scope, _ := c.metadataScope(ctx, opts)
+		response.DefaultMetric = c.metadata.defaultMetric(scope, strings.TrimSpace(name))
+	}
+	return response, err
+}
+
+func (c *Client) SearchCollection(ctx context.Context, collection string, query []float32, options *SearchOptions, opts ...CallOption) (SearchResponse, error) {
+	response, err := c.searchCollection(ctx, collection, query, options, opts)
+	if err == nil && options != nil && options.RoundScoresTo != nil {
+		response.Value = RoundScore(response.Value, *options.RoundScoresTo)
+	}
 	return response, err
 }
 
-func (c *Client) SearchCollection(ctx context.Context, collection string, query []float32, options *SearchOptions) (SearchResponse, error) {
-	body := c.searchBody(query, options)
-	path := fmt.Sprintf("/collections/%s/search", url.PathEscape(strings.TrimSpace(collection)))
+func (c *Client) searchCollection(ctx context.Context, collection string, query []float32, options *SearchOptions, opts []CallOption) (SearchResponse, error) {
+	if options != nil && options.ConsistentRead {
+		return c.consistentSearch(ctx, collection, query, options, opts)
+	}
+	excluded := excludedIDSet(options)
+	if len(excluded) > 0 && !c.capabilities.supported(capabilityExcludeIDs) {
+		return c.searchWithoutExcluded(ctx, collection, query, options, opts)
+	}
+	body, err := c.searchBody(query, options)
+	if err != nil {
+		return SearchResponse{}, err
+	}
+	if err := c.applyCollectionDefaults(ctx, collection, body, options, opts); err != nil {
+		return SearchResponse{}, err
+	}
+	path := fmt.Sprintf("/collections/%s/search", collectionPathSegment(collection))
 	var response SearchResponse
-	err := c.requestJSON(ctx, http.MethodPost, path, body, &response)
+	err = c.requestJSON(ctx, http.MethodPost, path, body, &response, opts...)
+	if err != nil {
+		return response, err
+	}
+	if isExcluded(excluded, response.ID) {
+		c.capabilities.markUnsupported(capabilityExcludeIDs)
+		return c.searchWithoutExcluded(ctx, collection, query, options, opts)
+	}
+	return response, nil
+}
+
+func (c *Client) SearchCollectionTopK(ctx context.Context, collection string, query []float32, options *SearchTopKOptions, opts ...CallOption) (SearchTopKResponse, error) {
+	response, err := c.searchCollectionTopK(ctx, collection, query, options, opts)
+	if err == nil {
+		roundHits(response.Hits, topKSearchOptions(options))
+	}
 	return response, err
 }
 
-func (c *Client) SearchCollectionTopK(ctx context.Context, collection string, query []float32, options *SearchTopKOptions) (SearchTopKResponse, error) {
+func (c *Client) searchCollectionTopK(ctx context.Context, collection string, query []float32, options *SearchTopKOptions, opts []CallOption) (SearchTopKResponse, error) {
 	body, err := c.searchTopKBody(query, options)
 	if err != nil {
 		return SearchTopKResponse{}, err
 	}
-	path := fmt.Sprintf("/collections/%s/search/topk", url.PathEscape(strings.TrimSpace(collection)))
+	if err := c.applyCollectionDefaults(ctx, collection, body, topKSearchOptions(options), opts); err != nil {
+		return SearchTopKResponse{}, err
+	}
+	if options != nil && options.ConsistentRead {
+		return c.consistentSearchTopK(ctx, collection, query, options, requestedLimit(body), opts)
+	}
+	path := fmt.Sprintf("/collections/%s/search/topk", collectionPathSegment(collection))
+	call := newCallConfig(opts)
+	if c.searchCache == nil || call.noCache || call.capturesResponse() {
+		return c.coalescedSearchTopK(ctx, path, body, options, call, opts)
+	}
+	key, cacheable := c.searchCacheKey(ctx, path, body, call)
+	if !cacheable {
+		return c.coalescedSearchTopK(ctx, path, body, options, call, opts)
+	}
+	if response, found := c.searchCache.get(key); found {
+		return response, nil
+	}
+	response, err := c.coalescedSearchTopK(ctx, path, body, options, call, opts)
+	if err == nil {
+		c.searchCache.put(key, response)
+	}
+	return response, err
+}
+
+func (c *Client) searchTopK(ctx context.Context, path string, body map[string]any, options *SearchTopKOptions, opts []CallOption) (SearchTopKResponse, error) {
+	excluded := excludedIDSet(topKSearchOptions(options))
+	if len(excluded) > 0 && !c.capabilities.supported(capabilityExcludeIDs) {
+		return c.searchTopKWithoutExcluded(ctx, path, body, excluded, opts)
+	}
 	var response SearchTopKResponse
-	err = c.requestJSON(ctx, http.MethodPost, path, body, &response)
+	err := c.requestJSON(ctx, http.MethodPost, path, body, &response, opts...)
+	if err != nil {
+		return response, err
+	}
+	if hitsLeakExcluded(response.Hits, excluded) {
+		c.capabilities.markUnsupported(capabilityExcludeIDs)
+		return c.searchTopKWithoutExcluded(ctx, path, body, excluded, opts)
+	}
+	return response, nil
+}
+
+func (c *Client) SearchCollectionTopKBatch(ctx context.Context, collection string, queries [][]float32, options *SearchTopKOptions, opts ...CallOption) (SearchTopKBatchResponse, error) {
+	response, err := c.searchCollectionTopKBatch(ctx, collection, queries, options, opts)
+	if err == nil {
+		for _, item := range response.Results {
+			roundHits(item.Hits, topKSearchOptions(options))
+		}
+	}
 	return response, err
 }
 
-func (c *Client) SearchCollectionTopKBatch(ctx context.Context, collection string, queries [][]float32, options *SearchTopKOptions) (SearchTopKBatchResponse, error) {
-	body, err := c.searchTopKBody(nil, options)
+func (c *Client) searchCollectionTopKBatch(ctx context.Context, collection string, queries [][]float32, options *SearchTopKOptions, opts []CallOption) (SearchTopKBatchResponse, error) {
+	if err := validateBatchQueries(queries); err != nil {
+		return SearchTopKBatchResponse{}, err
+	}
+	if err := c.checkBatchSize(len(queries)); err != nil {
+		return SearchTopKBatchResponse{}, err
+	}
+	if c.shouldChunkBatch(len(queries)) {
+		return c.searchTopKBatchChunked(ctx, collection, queries, options, opts)
+	}
+	return c.searchTopKBatch(ctx, collection, queries, options, opts)
+}
+
+func (c *Client) searchTopKBatch(ctx context.Context, collection string, queries [][]float32, options *SearchTopKOptions, opts []CallOption) (SearchTopKBatchResponse, error) {
+	body, err := c.searchTopKBody(queries[0], options)
 	if err != nil {
 		return SearchTopKBatchResponse{}, err
 	}
+	if err := c.applyCollectionDefaults(ctx, collection, body, topKSearchOptions(options), opts); err != nil {
+		return SearchTopKBatchResponse{}, err
+	}
 	body["queries"] = queries
 	delete(body, "query")
-	path := fmt.Sprintf("/collections/%s/search/topk/batch", url.PathEscape(strings.TrimSpace(collection)))
+	path := fmt.Sprintf("/collections/%s/search/topk/batch", collectionPathSegment(collection))
+	excluded := excludedIDSet(topKSearchOptions(options))
+	if len(excluded) > 0 && !c.capabilities.supported(capabilityExcludeIDs) {
+		return c.searchTopKBatchWithoutExcluded(ctx, path, body, excluded, opts)
+	}
 	var response SearchTopKBatchResponse
-	err = c.requestJSON(ctx, http.MethodPost, path, body, &response)
-	return response, err
+	err = c.requestJSON(ctx, http.MethodPost, path, body, &response, opts...)
+	if err != nil {
+		return response, err
+	}
+	for _, item := range response.Results {
+		if hitsLeakExcluded(item.Hits, excluded) {
+			c.capabilities.markUnsupported(capabilityExcludeIDs)
+			return c.searchTopKBatchWithoutExcluded(ctx, path, body, excluded, opts)
+		}
+	}
+	return response, nil
+}
+
+func (c *Client) UpsertPoint(ctx context.Context, collection string, pointID uint64, values []float32, payload PointPayload, opts ...CallOption) (UpsertPointResponse, error) {
+	return c.UpsertPointWithOptions(ctx, collection, pointID, values, payload, nil, opts...)
 }
 
-func (c *Client) UpsertPoint(ctx context.Context, collection string, pointID uint64, values []float32, payload PointPayload) (UpsertPointResponse, error) {
-	body := map[string]any{"values": values}
-	if payload != nil {
-		body["payload"] = payload
+func (c *Client) UpsertPointsBatch(ctx context.Context, collection string, points []UpsertPointsBatchItem, opts ...CallOption) (UpsertPointsBatchResponse, error) {
+	return c.UpsertPointsBatchWithOptions(ctx, collection, points, nil, opts...)
+}
+
+func (c *Client) UpsertPointsBatchWithOptions(ctx context.Context, collection string, points []UpsertPointsBatchItem, options *UpsertPointsBatchOptions, opts ...CallOption) (UpsertPointsBatchResponse, error) {
+	if c.rejectDuplicateIDs {
+		if duplicates := duplicatePointIDs(points); len(duplicates) > 0 {
+			return UpsertPointsBatchResponse{}, fmt.Errorf("duplicate point ids in batch: %s", joinPointIDs(duplicates))
+		}
 	}
-	path := fmt.Sprintf(pointPathFormat, url.PathEscape(strings.TrimSpace(collection)), pointID)
-	var response UpsertPointResponse
-	err := c.requestJSON(ctx, http.MethodPut, path, body, &response)
-	return response, err
+	if err := c.checkBatchSize(len(points)); err != nil {
+		return UpsertPointsBatchResponse{}, err
+	}
+	if c.shouldChunkBatch(len(points)) {
+		return c.upsertPointsBatchChunked(ctx, collection, points, options, opts)
+	}
+	return c.upsertPointsBatchSplitting(ctx, collection, points, options, opts)
 }
 
-func (c *Client) UpsertPointsBatch(ctx context.Context, collection string, points []UpsertPointsBatchItem) (UpsertPointsBatchResponse, error) {
+func (c *Client) upsertPointsBatch(ctx context.Context, collection string, points []UpsertPointsBatchItem, options *UpsertPointsBatchOptions, opts []CallOption) (UpsertPointsBatchResponse, error) {
 	body := map[string]any{"points": points}
-	path := fmt.Sprintf("/collections/%s/points", url.PathEscape(strings.TrimSpace(collection)))
+	path := fmt.Sprintf("/collections/%s/points", collectionPathSegment(collection))
+	if options != nil && options.ReturnResults != nil && !*options.ReturnResults {
+		path += "?results=false"
+	} else if options != nil && options.FailuresOnly {
+		path += "?results=failures"
+	}
+	dimension := 0
+	if len(points) > 0 {
+		dimension = len(points[0].Values)
+	}
+	defer c.pointCache.invalidateItems(strings.TrimSpace(collection), points)
 	var response UpsertPointsBatchResponse
-	err := c.requestJSON(ctx, http.MethodPost, path, body, &response)
+	err := c.withAutoCreate(ctx, collection, dimension, opts, func() error {
+		return c.requestJSON(ctx, http.MethodPost, path, body, &response, opts...)
+	})
+	if err == nil {
+		c.recentWrites.recordItems(strings.TrimSpace(collection), points, time.Now())
+	}
+	if options != nil && options.FailuresOnly {
+		response.Results = failedResults(response.Results)
+	}
 	return response, err
 }
 
-func (c *Client) GetPoint(ctx context.Context, collection string, pointID uint64) (PointResponse, error) {
-	path := fmt.Sprintf(pointPathFormat, url.PathEscape(strings.TrimSpace(collection)), pointID)
+func (c *Client) GetPoint(ctx context.Context, collection string, pointID uint64, opts ...CallOption) (PointResponse, error) {
+	scope, cached := c.pointCacheScope(ctx, newCallConfig(opts))
+	if cached {
+		if point, found := c.pointCache.get(scope, strings.TrimSpace(collection), pointID); found {
+			return point, nil
+		}
+	}
+	path := fmt.Sprintf(pointPathFormat, collectionPathSegment(collection), pointID)
 	var response PointResponse
-	err := c.requestJSON(ctx, http.MethodGet, path, nil, &response)
+	err := c.requestJSON(ctx, http.MethodGet, path, nil, &response, opts...)
+	if err == nil && cached {
+		c.pointCache.put(scope, strings.TrimSpace(collection), response)
+	}
 	return response, err
 }
 
-func (c *Client) ListPoints(ctx context.Context, collection string, options *ListPointsOptions) (ListPointsResponse, error) {
+func (c *Client) ListPoints(ctx context.Context, collection string, options *ListPointsOptions, opts ...CallOption) (ListPointsResponse, error) {
 	offset := 0
 	limit := 100
 	includeLimit := true
 	var afterID *uint64
+	var pageToken *string
+	includePayload := false
+	var fields []string
 	if options != nil {
 		offset = options.Offset
 		afterID = options.AfterID
+		pageToken = options.PageToken
+		includePayload = options.IncludePayload || len(options.Fields) > 0
+		fields = options.Fields
 		if options.Limit == nil {
 			includeLimit = false
 		} else {
@@ -218,6 +508,9 @@ func (c *Client) ListPoints(ctx context.Context, collection string, options *Lis
 	if afterID != nil && offset != 0 {
 		return ListPointsResponse{}, fmt.Errorf("offset must be 0 when afterID is provided")
 	}
+	if pageToken != nil && (afterID != nil || offset != 0) {
+		return ListPointsResponse{}, fmt.Errorf("pageToken cannot be combined with offset or afterID")
+	}
 	if includeLimit && limit <= 0 {
 		return ListPointsResponse{}, fmt.Errorf("limit must be a positive integer")
 	}
@@ -226,38 +519,81 @@ func (c *Client) ListPoints(ctx context.Context, collection string, options *Lis
 	if includeLimit {
 		params.Set("limit", strconv.Itoa(limit))
 	}
-	if afterID != nil {
+	if pageToken != nil {
+		params.Set("page_token", *pageToken)
+	} else if afterID != nil {
 		params.Set("after_id", strconv.FormatUint(*afterID, 10))
 	} else {
 		params.Set("offset", strconv.Itoa(offset))
 	}
-	path := fmt.Sprintf("/collections/%s/points?%s", url.PathEscape(strings.TrimSpace(collection)), params.Encode())
+	if includePayload {
+		params.Set("include_payload", "true")
+	}
+	if len(fields) > 0 {
+		params.Set("fields", strings.Join(fields, ","))
+	}
+	path := fmt.Sprintf("/collections/%s/points?%s", collectionPathSegment(collection), params.Encode())
 	var response ListPointsResponse
-	err := c.requestJSON(ctx, http.MethodGet, path, nil, &response)
+	err := c.requestJSON(ctx, http.MethodGet, path, nil, &response, opts...)
+	if err == nil && len(fields) > 0 {
+		for index := range response.Points {
+			response.Points[index].Payload = projectPayload(response.Points[index].Payload, fields)
+		}
+	}
 	return response, err
 }
 
-func (c *Client) DeletePoint(ctx context.Context, collection string, pointID uint64) (DeletePointResponse, error) {
-	path := fmt.Sprintf(pointPathFormat, url.PathEscape(strings.TrimSpace(collection)), pointID)
+func projectPayload(payload PointPayload, fields []string) PointPayload {
+	if payload == nil {
+		return nil
+	}
+	projected := make(PointPayload, len(fields))
+	for _, field := range fields {
+		if value, found := payload[field]; found {
+			projected[field] = value
+		}
+	}
+	return projected
+}
+
+func (c *Client) DeletePoint(ctx context.Context, collection string, pointID uint64, opts ...CallOption) (DeletePointResponse, error) {
+	path := fmt.Sprintf(pointPathFormat, collectionPathSegment(collection), pointID)
+	defer c.pointCache.invalidate(strings.TrimSpace(collection), pointID)
+	defer c.recentWrites.forget(strings.TrimSpace(collection), pointID)
 	var response DeletePointResponse
-	err := c.requestJSON(ctx, http.MethodDelete, path, nil, &response)
+	empty, err := c.requestJSONOrEmpty(ctx, http.MethodDelete, path, nil, &response, opts...)
+	if err == nil && empty {
+		response = DeletePointResponse{ID: pointID, Deleted: true}
+	}
 	return response, err
 }
 
-func (c *Client) DeleteCollection(ctx context.Context, name string) (DeleteCollectionResponse, error) {
-	path := fmt.Sprintf("/collections/%s", url.PathEscape(strings.TrimSpace(name)))
+func (c *Client) DeleteCollection(ctx context.Context, name string, opts ...CallOption) (DeleteCollectionResponse, error) {
+	path := fmt.Sprintf("/collections/%s", collectionPathSegment(name))
+	c.invalidateMetadata(ctx, name, opts)
+	defer c.pointCache.invalidateCollection(strings.TrimSpace(name))
+	defer c.recentWrites.forget(strings.TrimSpace(name))
 	var response DeleteCollectionResponse
-	err := c.requestJSON(ctx, http.MethodDelete, path, nil, &response)
+	empty, err := c.requestJSONOrEmpty(ctx, http.MethodDelete, path, nil, &response, opts...)
+	if err == nil && empty {
+		response = DeleteCollectionResponse{Name: strings.TrimSpace(name), Deleted: true}
+	}
 	return response, err
 }
 
-func (c *Client) searchBody(query []float32, options *SearchOptions) map[string]any {
-	metric := MetricDot
-	mode := SearchModeAuto
+func (c *Client) searchBody(query []float32, options *SearchOptions) (map[string]any, error) {
+	if len(query) == 0 {
+		return nil, fmt.Errorf("query vector must not be empty")
+	}
+	metric := c.withMetricDefault("")
+	mode := c.withModeDefault("")
 	body := map[string]any{"query": query}
+	if c.includePayloadByDefault != nil {
+		body["include_payload"] = *c.includePayloadByDefault
+	}
 	if options != nil {
-		metric = withMetricDefault(options.Metric)
-		mode = withModeDefault(options.Mode)
+		metric = c.withMetricDefault(options.Metric)
+		mode = c.withModeDefault(options.Mode)
 		if options.TargetRecall != nil {
 			body["target_recall"] = *options.TargetRecall
 		}
@@ -267,21 +603,20 @@ func (c *Client) searchBody(query []float32, options *SearchOptions) map[string]
 		if options.IncludePayload != nil {
 			body["include_payload"] = *options.IncludePayload
 		}
-	} else {
-		metric = MetricDot
-		mode = SearchModeAuto
+		if len(options.ExcludeIDs) > 0 {
+			body["exclude_ids"] = options.ExcludeIDs
+		}
 	}
 	body["metric"] = metric
 	body["mode"] = mode
-	return body
+	return body, nil
 }
 
 func (c *Client) searchTopKBody(query []float32, options *SearchTopKOptions) (map[string]any, error) {
-	searchOptions := (*SearchOptions)(nil)
-	if options != nil {
-		searchOptions = &options.SearchOptions
+	body, err := c.searchBody(query, topKSearchOptions(options))
+	if err != nil {
+		return nil, err
 	}
-	body := c.searchBody(query, searchOptions)
 	limit := 10
 	limitSet := options == nil
 	if options != nil && options.Limit != nil {
@@ -297,100 +632,50 @@ func (c *Client) searchTopKBody(query []float32, options *SearchTopKOptions) (ma
 	return body, nil
 }
 
-func withMetricDefault(metric Metric) Metric {
-	if metric == "" {
-		return MetricDot
-	}
-	return metric
-}
-
-func withModeDefault(mode SearchMode) SearchMode {
-	if mode == "" {
-		return SearchModeAuto
+func validateBatchQueries(queries [][]float32) error {
+	if len(queries) == 0 {
+		return fmt.Errorf("queries must not be empty")
 	}
-	return mode
-}
-
-func (c *Client) requestJSON(ctx context.Context, method string, path string, body any, out any) error {
-	payload, err := c.doRequest(ctx, method, path, body, false)
-	if err != nil {
-		return err
-	}
-	if len(bytes.TrimSpace(payload)) == 0 {
-		return nil
-	}
-	if err := json.Unmarshal(payload, out); err != nil {
-		return &Error{
-			Method: method,
-			Path:   path,
-			Body:   string(payload),
-			Err:    fmt.Errorf("invalid JSON response: %w", err),
+	for index, query := range queries {
+		if len(query) == 0 {
+			return fmt.Errorf("queries[%d]: query vector must not be empty", index)
 		}
 	}
 	return nil
 }
 
-func (c *Client) requestRaw(ctx context.Context, method string, path string, body any) (string, error) {
-	payload, err := c.doRequest(ctx, method, path, body, true)
-	if err != nil {
-		return "", err
+func topKSearchOptions(options *SearchTopKOptions) *SearchOptions {
+	if options == nil {
+		return nil
 	}
-	return string(payload), nil
+	return &options.SearchOptions
 }
 
-func (c *Client) doRequest(ctx context.Context, method string, path string, body any, raw bool) ([]byte, error) {
-	if ctx == nil {
-		ctx = context.Background()
+func (c *Client) withMetricDefault(metric Metric) Metric {
+	if metric != "" {
+		return metric
 	}
-
-	var requestBody io.Reader
-	if body != nil {
-		encoded, err := json.Marshal(body)
-		if err != nil {
-			return nil, &Error{Method: method, Path: path, Err: err}
-		}
-		requestBody = bytes.NewReader(encoded)
+	if c.defaultMetric != "" {
+		return c.defaultMetric
 	}
+	return MetricDot
+}
 
-	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, requestBody)
-	if err != nil {
-		return nil, &Error{Method: method, Path: path, Err: err}
-	}
-	if raw {
-		request.Header.Set("Accept", "text/plain")
-	} else {
-		request.Header.Set("Accept", "application/json")
-	}
-	for key, value := range c.defaultHeader {
-		request.Header.Set(key, value)
-	}
-	if c.apiKey != "" {
-		request.Header.Set("x-api-key", c.apiKey)
-	}
-	if c.bearerToken != "" {
-		request.Header.Set("Authorization", "Bearer "+c.bearerToken)
-	}
-	if body != nil {
-		request.Header.Set("Content-Type", "application/json")
+func validateMetric(metric Metric) error {
+	switch metric {
+	case MetricDot, MetricL2, MetricCosine:
+		return nil
+	default:
+		return fmt.Errorf("metric must be one of dot, l2, cosine, got %q", metric)
 	}
+}
 
-	response, err := c.httpClient.Do(request)
-	if err != nil {
-		return nil, &Error{Method: method, Path: path, Err: err}
+func (c *Client) withModeDefault(mode SearchMode) SearchMode {
+	if mode != "" {
+		return mode
 	}
-	defer response.Body.Close()
-
-	responseBody, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, &Error{Method: method, Path: path, Err: err}
-	}
-	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		return nil, &Error{
-			Status: response.StatusCode,
-			Method: method,
-			Path:   path,
-			Body:   string(responseBody),
-		}
+	if c.defaultMode != "" {
+		return c.defaultMode
 	}
-	return responseBody, nil
+	return SearchModeAuto
 }
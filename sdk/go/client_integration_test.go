@@ -3,20 +3,32 @@ package aionbd
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 )
 
-const serverReadyTimeout = 90 * time.Second
+const (
+	serverReadyTimeout = 90 * time.Second
+
+	testCollectionPrefix = "go_sdk_demo_"
+	staleCollectionAge   = time.Hour
+	// testSeedEnv overrides the seed behind test collection names.
+	testSeedEnv     = "AIONBD_SDK_TEST_SEED"
+	defaultTestSeed = "aionbd"
+)
 
 func TestClientIntegration(t *testing.T) {
 	if testing.Short() {
@@ -28,9 +40,13 @@ func TestClientIntegration(t *testing.T) {
 
 	client := NewClient(baseURL, &ClientOptions{Timeout: 10 * time.Second})
 	ctx := context.Background()
-	collectionName := fmt.Sprintf("go_sdk_demo_%d", time.Now().UnixNano())
+	collectionName := testCollectionName(t)
 
 	requireLiveAndReady(t, ctx, client)
+	cleanupStaleCollections(ctx, client)
+	// A previous run of this test that failed before its cleanup leaves its
+	// collection behind under the same name.
+	_, _ = client.DeleteCollection(ctx, collectionName)
 	requireCollectionCreated(t, ctx, client, collectionName)
 	t.Cleanup(func() {
 		_, _ = client.DeleteCollection(context.Background(), collectionName)
@@ -45,6 +61,83 @@ func TestClientIntegration(t *testing.T) {
 	requireMetrics(t, ctx, client)
 }
 
+// testCollectionName derives a reproducible collection name from the test
+// seed and t.Name(), so re-runs use the same name.
+func testCollectionName(t *testing.T) string {
+	seed := os.Getenv(testSeedEnv)
+	if seed == "" {
+		seed = defaultTestSeed
+	}
+	sum := sha256.Sum256([]byte(seed + "/" + t.Name()))
+	return testCollectionPrefix + hex.EncodeToString(sum[:6])
+}
+
+// cleanupStaleCollections deletes test collections of earlier harness
+// versions whose name ends in a creation timestamp older than
+// staleCollectionAge: unix nanoseconds, or "<stem>_<unix seconds>". Seeded
+// names carry no timestamp and are left to the test that owns them. Failures
+// are ignored; the sweep is best effort.
+func cleanupStaleCollections(ctx context.Context, client *Client) {
+	collections, err := client.ListCollections(ctx)
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().Add(-staleCollectionAge)
+	for _, collection := range collections.Collections {
+		created, ok := testCollectionCreatedAt(collection.Name)
+		if ok && created.Before(cutoff) {
+			_, _ = client.DeleteCollection(ctx, collection.Name)
+		}
+	}
+}
+
+func testCollectionCreatedAt(name string) (time.Time, bool) {
+	suffix, found := strings.CutPrefix(name, testCollectionPrefix)
+	if !found {
+		return time.Time{}, false
+	}
+	if _, seconds, stamped := strings.Cut(suffix, "_"); stamped {
+		unix, err := strconv.ParseInt(seconds, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(unix, 0), true
+	}
+	nanos, err := strconv.ParseInt(suffix, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
+
+func TestCleanupStaleCollectionsDeletesOnlyOldTestCollections(t *testing.T) {
+	t.Parallel()
+
+	legacy := fmt.Sprintf("%s%d", testCollectionPrefix, time.Now().Add(-2*time.Hour).UnixNano())
+	old := fmt.Sprintf("%s_%d", testCollectionName(t), time.Now().Add(-2*time.Hour).Unix())
+	recent := fmt.Sprintf("%s_%d", testCollectionName(t), time.Now().Unix())
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodDelete {
+			mu.Lock()
+			deleted = append(deleted, strings.TrimPrefix(request.URL.Path, "/collections/"))
+			mu.Unlock()
+			writeJSON(t, writer, map[string]any{"name": "x", "deleted": true})
+			return
+		}
+		writeJSON(t, writer, map[string]any{"collections": []map[string]any{
+			{"name": legacy}, {"name": old}, {"name": recent}, {"name": testCollectionName(t)}, {"name": "user_data"}, {"name": testCollectionPrefix + "notes"},
+		}})
+	}))
+	defer server.Close()
+
+	cleanupStaleCollections(context.Background(), NewClient(server.URL, nil))
+	if len(deleted) != 2 || deleted[0] != legacy || deleted[1] != old {
+		t.Fatalf("expected only %s and %s deleted, got %v", legacy, old, deleted)
+	}
+}
+
 func startServer(t *testing.T) (string, func()) {
 	t.Helper()
 
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -97,6 +99,50 @@ func TestListPointsOmitLimitInCursorMode(t *testing.T) {
 	}
 }
 
+func TestListPointsRequestsSparsePayloadFields(t *testing.T) {
+	t.Parallel()
+
+	var capturedQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		capturedQuery = request.URL.Query()
+		writeJSON(t, writer, map[string]any{
+			"points": []map[string]any{
+				{"id": 1, "payload": map[string]any{"label": "alpha", "score": 3}},
+				{"id": 2, "payload": map[string]any{"label": "beta", "secret": "x"}},
+			},
+			"total":         2,
+			"next_offset":   nil,
+			"next_after_id": nil,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	response, err := client.ListPoints(context.Background(), "demo", &ListPointsOptions{
+		Limit:  IntPtr(10),
+		Fields: []string{"label", "score"},
+	})
+	if err != nil {
+		t.Fatalf("list points failed: %v", err)
+	}
+	if capturedQuery.Get("fields") != "label,score" || capturedQuery.Get("include_payload") != "true" {
+		t.Fatalf("unexpected query: %s", capturedQuery.Encode())
+	}
+	if len(response.Points) != 2 {
+		t.Fatalf("unexpected points: %#v", response.Points)
+	}
+	for _, point := range response.Points {
+		for key := range point.Payload {
+			if key != "label" && key != "score" {
+				t.Fatalf("unexpected payload field %q on point %d", key, point.ID)
+			}
+		}
+	}
+	if response.Points[1].Payload["label"] != "beta" {
+		t.Fatalf("expected requested field to be kept: %#v", response.Points[1].Payload)
+	}
+}
+
 func TestListPointsRejectsMixedOffsetAndAfterID(t *testing.T) {
 	t.Parallel()
 
@@ -110,6 +156,31 @@ func TestListPointsRejectsMixedOffsetAndAfterID(t *testing.T) {
 	}
 }
 
+func TestUpsertPointsBatchCanOmitResults(t *testing.T) {
+	t.Parallel()
+
+	var capturedQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		capturedQuery = request.URL.RawQuery
+		writeJSON(t, writer, map[string]any{"created": 2, "updated": 1})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	response, err := client.UpsertPointsBatchWithOptions(context.Background(), "demo", []UpsertPointsBatchItem{
+		{ID: 1, Values: []float32{1}},
+	}, &UpsertPointsBatchOptions{ReturnResults: BoolPtr(false)})
+	if err != nil {
+		t.Fatalf("batch upsert failed: %v", err)
+	}
+	if capturedQuery != "results=false" {
+		t.Fatalf("unexpected query: %s", capturedQuery)
+	}
+	if response.Created != 2 || response.Updated != 1 || response.Results != nil {
+		t.Fatalf("unexpected response: %#v", response)
+	}
+}
+
 func TestClientAddsAuthHeaders(t *testing.T) {
 	t.Parallel()
 
@@ -137,6 +208,59 @@ func TestClientAddsAuthHeaders(t *testing.T) {
 	}
 }
 
+func TestClientSendsEveryMultiHeaderValue(t *testing.T) {
+	t.Parallel()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		received = request.Header.Values("X-Forwarded-For")
+		writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 1})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{
+		MultiHeaders: http.Header{"X-Forwarded-For": {"10.0.0.1", "10.0.0.2"}},
+	})
+	if _, err := client.Live(context.Background()); err != nil {
+		t.Fatalf("live failed: %v", err)
+	}
+	if len(received) != 2 || received[0] != "10.0.0.1" || received[1] != "10.0.0.2" {
+		t.Fatalf("unexpected X-Forwarded-For values: %#v", received)
+	}
+}
+
+func TestDistanceEchoInputsOnlyWhenRequested(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		response := map[string]any{"metric": "cosine", "value": 1.0}
+		if request.URL.Query().Get("echo") == "true" {
+			response["left"] = []float32{0.6, 0.8}
+			response["right"] = []float32{0.6, 0.8}
+		}
+		writeJSON(t, writer, response)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	left, right := []float32{3, 4}, []float32{6, 8}
+	echoed, err := client.DistanceWithOptions(context.Background(), left, right, MetricCosine, &DistanceOptions{EchoInputs: true})
+	if err != nil {
+		t.Fatalf("distance with echo failed: %v", err)
+	}
+	if len(echoed.Left) != 2 || echoed.Left[0] != 0.6 || len(echoed.Right) != 2 || echoed.Right[1] != 0.8 {
+		t.Fatalf("unexpected echoed inputs: %+v", echoed)
+	}
+
+	plain, err := client.Distance(context.Background(), left, right, MetricCosine)
+	if err != nil {
+		t.Fatalf("distance failed: %v", err)
+	}
+	if plain.Left != nil || plain.Right != nil {
+		t.Fatalf("expected no echoed inputs, got %+v", plain)
+	}
+}
+
 func TestMetricsPrometheusReturnsRawText(t *testing.T) {
 	t.Parallel()
 
@@ -208,3 +332,187 @@ func writeJSON(t *testing.T, writer http.ResponseWriter, payload map[string]any)
 		t.Fatalf("encode response: %v", err)
 	}
 }
+
+func TestDeleteOperationsInferDeletedFromNoContent(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodDelete {
+			t.Fatalf("unexpected method: %s", request.Method)
+		}
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	point, err := client.DeletePoint(context.Background(), "demo", 7)
+	if err != nil {
+		t.Fatalf("delete point failed: %v", err)
+	}
+	if !point.Deleted || point.ID != 7 {
+		t.Fatalf("unexpected delete point response: %#v", point)
+	}
+
+	collection, err := client.DeleteCollection(context.Background(), "demo")
+	if err != nil {
+		t.Fatalf("delete collection failed: %v", err)
+	}
+	if !collection.Deleted || collection.Name != "demo" {
+		t.Fatalf("unexpected delete collection response: %#v", collection)
+	}
+}
+
+func TestClientDefaultMetricAppliesWhenCallOmitsMetric(t *testing.T) {
+	t.Parallel()
+
+	var metrics []any
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		metrics = append(metrics, body["metric"])
+		writeJSON(t, writer, map[string]any{"metric": body["metric"], "mode": "exact", "hits": []any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{DefaultMetric: MetricCosine})
+	if _, err := client.SearchCollectionTopK(context.Background(), "demo", []float32{1, 0}, nil); err != nil {
+		t.Fatalf("search without options failed: %v", err)
+	}
+	options := &SearchTopKOptions{SearchOptions: SearchOptions{Metric: MetricL2}}
+	if _, err := client.SearchCollectionTopK(context.Background(), "demo", []float32{1, 0}, options); err != nil {
+		t.Fatalf("search with explicit metric failed: %v", err)
+	}
+	if len(metrics) != 2 || metrics[0] != "cosine" || metrics[1] != "l2" {
+		t.Fatalf("unexpected metrics sent: %#v", metrics)
+	}
+
+	invalid := NewClient(server.URL, &ClientOptions{DefaultMetric: "manhattan"})
+	if invalid.ConfigError() == nil {
+		t.Fatal("expected invalid default metric to be rejected")
+	}
+}
+
+func TestClientDefaultModeAppliesWhenCallOmitsMode(t *testing.T) {
+	t.Parallel()
+
+	var modes []any
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		modes = append(modes, body["mode"])
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": body["mode"], "hits": []any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{DefaultMode: SearchModeExact})
+	if _, err := client.SearchCollectionTopK(context.Background(), "demo", []float32{1, 0}, nil); err != nil {
+		t.Fatalf("search without options failed: %v", err)
+	}
+	options := &SearchTopKOptions{SearchOptions: SearchOptions{Mode: SearchModeIVF}}
+	if _, err := client.SearchCollectionTopK(context.Background(), "demo", []float32{1, 0}, options); err != nil {
+		t.Fatalf("search with explicit mode failed: %v", err)
+	}
+	if len(modes) != 2 || modes[0] != "exact" || modes[1] != "ivf" {
+		t.Fatalf("unexpected modes sent: %#v", modes)
+	}
+}
+
+func TestSearchRejectsEmptyQueryLocally(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		t.Fatalf("empty query reached the server: %s", request.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ctx := context.Background()
+	if _, err := client.SearchCollection(ctx, "demo", nil, nil); err == nil || !strings.Contains(err.Error(), "query vector must not be empty") {
+		t.Fatalf("expected empty query error, got: %v", err)
+	}
+	if _, err := client.SearchCollectionTopK(ctx, "demo", []float32{}, nil); err == nil || !strings.Contains(err.Error(), "query vector must not be empty") {
+		t.Fatalf("expected empty top-k query error, got: %v", err)
+	}
+	_, err := client.SearchCollectionTopKBatch(ctx, "demo", [][]float32{{1, 0}, {}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "queries[1]: query vector must not be empty") {
+		t.Fatalf("expected empty batch query error, got: %v", err)
+	}
+}
+
+func TestClientIncludePayloadDefaultAppliesWhenOptionUnset(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": []any{}})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	query := []float32{1, 0}
+	if _, err := NewClient(server.URL, nil).SearchCollectionTopK(ctx, "demo", query, nil); err != nil {
+		t.Fatalf("search with server default failed: %v", err)
+	}
+	client := NewClient(server.URL, &ClientOptions{IncludePayloadByDefault: BoolPtr(false)})
+	if _, err := client.SearchCollectionTopK(ctx, "demo", query, nil); err != nil {
+		t.Fatalf("search with client default failed: %v", err)
+	}
+	options := &SearchTopKOptions{SearchOptions: SearchOptions{IncludePayload: BoolPtr(true)}}
+	if _, err := client.SearchCollectionTopK(ctx, "demo", query, options); err != nil {
+		t.Fatalf("search with per-call override failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 3 {
+		t.Fatalf("expected 3 search requests, got %d", len(bodies))
+	}
+	if _, sent := bodies[0]["include_payload"]; sent {
+		t.Fatalf("expected include_payload to be omitted without a client default: %#v", bodies[0])
+	}
+	if bodies[1]["include_payload"] != false {
+		t.Fatalf("expected client default to omit payloads: %#v", bodies[1])
+	}
+	if bodies[2]["include_payload"] != true {
+		t.Fatalf("expected per-call option to win: %#v", bodies[2])
+	}
+}
+
+func TestNonJSONContentTypeReportsActualType(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = writer.Write([]byte("<html><body>Gateway login</body></html>"))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, nil).Live(context.Background())
+	requestErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected *Error, got %T: %v", err, err)
+	}
+	if !strings.Contains(requestErr.Error(), `unexpected Content-Type "text/html; charset=utf-8"`) {
+		t.Fatalf("expected content type in error, got: %v", requestErr)
+	}
+	if !strings.Contains(requestErr.Body, "Gateway login") {
+		t.Fatalf("expected body to be kept for debugging, got: %q", requestErr.Body)
+	}
+
+	lenient := NewClient(server.URL, &ClientOptions{AllowNonJSONContentType: true})
+	if _, err := lenient.Live(context.Background()); err == nil || strings.Contains(err.Error(), "Content-Type") {
+		t.Fatalf("expected the lenient client to attempt decoding, got: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package aionbd
+
+import (
+	"net/http"
+	"time"
+)
+
+const defaultClockSkewThreshold = 30 * time.Second
+
+// checkClockSkew compares a response Date header with the local receive time.
+// The header has one-second resolution, so the threshold should stay well
+// above a second.
+func (c *Client) checkClockSkew(date string, now time.Time) {
+	if c.onClockSkew == nil || date == "" {
+		return
+	}
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return
+	}
+	threshold := c.clockSkewThreshold
+	if threshold <= 0 {
+		threshold = defaultClockSkewThreshold
+	}
+	skew := serverTime.Sub(now.Truncate(time.Second))
+	if skew > threshold || skew < -threshold {
+		c.onClockSkew(skew)
+	}
+}
@@ -0,0 +1,54 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOnClockSkewReportsServerAhead(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Date", time.Now().Add(2*time.Hour).UTC().Format(http.TimeFormat))
+		writeJSON(t, writer, map[string]any{"status": "live"})
+	}))
+	defer server.Close()
+
+	var reported []time.Duration
+	client := NewClient(server.URL, &ClientOptions{
+		OnClockSkew: func(skew time.Duration) { reported = append(reported, skew) },
+	})
+	if _, err := client.Live(context.Background()); err != nil {
+		t.Fatalf("Live returned error: %v", err)
+	}
+	if len(reported) != 1 {
+		t.Fatalf("expected one skew report, got %v", reported)
+	}
+	if reported[0] < 2*time.Hour-5*time.Second || reported[0] > 2*time.Hour+5*time.Second {
+		t.Fatalf("unexpected skew %s", reported[0])
+	}
+}
+
+func TestCheckClockSkewIgnoresSmallAndMalformedDates(t *testing.T) {
+	t.Parallel()
+
+	var reported []time.Duration
+	client := NewClient("http://127.0.0.1:1", &ClientOptions{
+		OnClockSkew:        func(skew time.Duration) { reported = append(reported, skew) },
+		ClockSkewThreshold: time.Minute,
+	})
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	client.checkClockSkew(now.Add(30*time.Second).Format(http.TimeFormat), now)
+	client.checkClockSkew("not a date", now)
+	client.checkClockSkew("", now)
+	if len(reported) != 0 {
+		t.Fatalf("expected no reports, got %v", reported)
+	}
+	client.checkClockSkew(now.Add(-2*time.Minute).Format(http.TimeFormat), now)
+	if len(reported) != 1 || reported[0] != -2*time.Minute {
+		t.Fatalf("expected -2m skew, got %v", reported)
+	}
+}
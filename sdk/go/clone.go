@@ -0,0 +1,45 @@
+package aionbd
+
+type Option func(*Client)
+
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.bearerToken = token
+	}
+}
+
+func WithDefaultHeader(key string, value string) Option {
+	return func(c *Client) {
+		c.defaultHeader[key] = value
+	}
+}
+
+// Clone returns a client whose auth credentials and default headers (single
+// and multi-value) are copied, so opts can override them without affecting
+// the original, and which starts with its own record of recent writes for
+// ConsistentRead. Everything else is shared with the receiver:
+//   - the *http.Client, and so its transport and connection pool;
+//   - the server capability cache, rate limiter, and server throttle, which
+//     describe the server rather than the caller;
+//   - the search, point, and metadata caches and the search coalescer, whose
+//     entries are keyed by the credentials and headers of each request, so a
+//     clone with different ones never reads the original's entries.
+func (c *Client) Clone(opts ...Option) *Client {
+	clone := *c
+	clone.defaultHeader = make(map[string]string, len(c.defaultHeader))
+	for key, value := range c.defaultHeader {
+		clone.defaultHeader[key] = value
+	}
+	clone.multiHeader = c.multiHeader.Clone()
+	clone.recentWrites = newRecentWrites()
+	for _, opt := range opts {
+		opt(&clone)
+	}
+	return &clone
+}
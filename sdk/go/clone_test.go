@@ -0,0 +1,85 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCloneOverridesAuthAndSharesTransport(t *testing.T) {
+	t.Parallel()
+
+	var apiKeys, tenants []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		apiKeys = append(apiKeys, request.Header.Get("x-api-key"))
+		tenants = append(tenants, request.Header.Get("X-Tenant"))
+		writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 1})
+	}))
+	defer server.Close()
+
+	original := NewClient(server.URL, &ClientOptions{
+		APIKey:  "key-a",
+		Headers: map[string]string{"X-Tenant": "tenant-a"},
+	})
+	clone := original.Clone(WithAPIKey("key-b"), WithDefaultHeader("X-Tenant", "tenant-b"))
+
+	if clone.httpClient != original.httpClient || clone.httpClient.Transport != original.httpClient.Transport {
+		t.Fatal("expected clone to share the underlying transport")
+	}
+	if clone.capabilities != original.capabilities {
+		t.Fatal("expected clone to share the capability cache")
+	}
+
+	if _, err := clone.Live(context.Background()); err != nil {
+		t.Fatalf("clone live failed: %v", err)
+	}
+	if _, err := original.Live(context.Background()); err != nil {
+		t.Fatalf("original live failed: %v", err)
+	}
+	if apiKeys[0] != "key-b" || tenants[0] != "tenant-b" {
+		t.Fatalf("clone did not apply overrides: key=%q tenant=%q", apiKeys[0], tenants[0])
+	}
+	if apiKeys[1] != "key-a" || tenants[1] != "tenant-a" {
+		t.Fatalf("original was mutated: key=%q tenant=%q", apiKeys[1], tenants[1])
+	}
+}
+
+func TestCloneKeepsPerCallerStateApart(t *testing.T) {
+	t.Parallel()
+
+	original := NewClient("http://aionbd.test", &ClientOptions{
+		APIKey:       "key-a",
+		MultiHeaders: http.Header{"X-Feature": {"a"}},
+		PointCache:   &PointCacheConfig{},
+	})
+	clone := original.Clone(WithAPIKey("key-b"))
+	ctx := context.Background()
+
+	clone.multiHeader.Add("X-Feature", "b")
+	if got := original.multiHeader.Values("X-Feature"); len(got) != 1 {
+		t.Fatalf("multi-value headers leaked into the original: %v", got)
+	}
+
+	clone.recentWrites.record("demo", 1, []float32{1}, time.Now())
+	if pending := original.recentWrites.pending("demo", time.Now()); len(pending) != 0 {
+		t.Fatalf("recent writes leaked into the original: %v", pending)
+	}
+
+	cloneScope, _ := clone.pointCacheScope(ctx, newCallConfig(nil))
+	originalScope, _ := original.pointCacheScope(ctx, newCallConfig(nil))
+	clone.pointCache.put(cloneScope, "demo", PointResponse{ID: 1})
+	if _, found := original.pointCache.get(originalScope, "demo", 1); found {
+		t.Fatal("the original read a point cached by the clone")
+	}
+	clone.metadata.put(cloneScope, "demo", CollectionResponse{Name: "demo", Dimension: 2})
+	if _, found := original.metadata.get(originalScope, "demo"); found {
+		t.Fatal("the original read metadata cached by the clone")
+	}
+	cloneKey, _ := clone.searchCacheKey(ctx, "/collections/demo/search/topk", map[string]any{"query": []float32{1}}, newCallConfig(nil))
+	originalKey, _ := original.searchCacheKey(ctx, "/collections/demo/search/topk", map[string]any{"query": []float32{1}}, newCallConfig(nil))
+	if cloneKey == originalKey {
+		t.Fatal("the clone and the original share search cache and coalescing keys")
+	}
+}
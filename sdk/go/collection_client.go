@@ -0,0 +1,58 @@
+package aionbd
+
+import "context"
+
+// CollectionClient is a Client bound to one collection. It shares the parent's
+// transport, options, and caches.
+type CollectionClient struct {
+	client *Client
+	name   string
+}
+
+func (c *Client) Collection(name string) *CollectionClient {
+	return &CollectionClient{client: c, name: name}
+}
+
+func (cc *CollectionClient) Name() string {
+	return cc.name
+}
+
+func (cc *CollectionClient) Client() *Client {
+	return cc.client
+}
+
+func (cc *CollectionClient) Info(ctx context.Context, opts ...CallOption) (CollectionResponse, error) {
+	return cc.client.GetCollection(ctx, cc.name, opts...)
+}
+
+func (cc *CollectionClient) Upsert(ctx context.Context, pointID uint64, values []float32, payload PointPayload, opts ...CallOption) (UpsertPointResponse, error) {
+	return cc.client.UpsertPoint(ctx, cc.name, pointID, values, payload, opts...)
+}
+
+func (cc *CollectionClient) UpsertBatch(ctx context.Context, points []UpsertPointsBatchItem, opts ...CallOption) (UpsertPointsBatchResponse, error) {
+	return cc.client.UpsertPointsBatch(ctx, cc.name, points, opts...)
+}
+
+func (cc *CollectionClient) Get(ctx context.Context, pointID uint64, opts ...CallOption) (PointResponse, error) {
+	return cc.client.GetPoint(ctx, cc.name, pointID, opts...)
+}
+
+func (cc *CollectionClient) Delete(ctx context.Context, pointID uint64, opts ...CallOption) (DeletePointResponse, error) {
+	return cc.client.DeletePoint(ctx, cc.name, pointID, opts...)
+}
+
+func (cc *CollectionClient) ListPoints(ctx context.Context, options *ListPointsOptions, opts ...CallOption) (ListPointsResponse, error) {
+	return cc.client.ListPoints(ctx, cc.name, options, opts...)
+}
+
+func (cc *CollectionClient) Search(ctx context.Context, query []float32, options *SearchOptions, opts ...CallOption) (SearchResponse, error) {
+	return cc.client.SearchCollection(ctx, cc.name, query, options, opts...)
+}
+
+func (cc *CollectionClient) SearchTopK(ctx context.Context, query []float32, options *SearchTopKOptions, opts ...CallOption) (SearchTopKResponse, error) {
+	return cc.client.SearchCollectionTopK(ctx, cc.name, query, options, opts...)
+}
+
+func (cc *CollectionClient) SearchTopKBatch(ctx context.Context, queries [][]float32, options *SearchTopKOptions, opts ...CallOption) (SearchTopKBatchResponse, error) {
+	return cc.client.SearchCollectionTopKBatch(ctx, cc.name, queries, options, opts...)
+}
@@ -0,0 +1,44 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollectionClientUsesBoundName(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		paths = append(paths, request.Method+" "+request.URL.Path)
+		switch request.URL.Path {
+		case "/collections/my docs/search":
+			writeJSON(t, writer, map[string]any{"id": 7, "metric": "dot", "value": 1.5, "mode": "exact"})
+		case "/collections/my docs/points/7":
+			writeJSON(t, writer, map[string]any{"id": 7, "values": []float32{1, 2}})
+		default:
+			t.Errorf("unexpected request %s %s", request.Method, request.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	docs := NewClient(server.URL, nil).Collection("my docs")
+	if docs.Name() != "my docs" {
+		t.Fatalf("unexpected name %q", docs.Name())
+	}
+	hit, err := docs.Search(context.Background(), []float32{1, 2}, nil)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if hit.ID != 7 {
+		t.Fatalf("unexpected search response: %+v", hit)
+	}
+	if _, err := docs.Get(context.Background(), 7); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if len(paths) != 2 || paths[0] != "POST /collections/my docs/search" || paths[1] != "GET /collections/my docs/points/7" {
+		t.Fatalf("unexpected requests: %v", paths)
+	}
+}
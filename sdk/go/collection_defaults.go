@@ -0,0 +1,60 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type CreateCollectionOptions struct {
+	StrictFinite  bool
+	DefaultMetric Metric
+}
+
+// CreateCollectionWithOptions creates a collection like CreateCollection and
+// records DefaultMetric as the metric for searches on it that set none, when
+// the client has no DefaultMetric either. The metric is also sent as
+// "default_metric"; servers that do not store it ignore the field, so the
+// default then lives only in this client.
+func (c *Client) CreateCollectionWithOptions(ctx context.Context, name string, dimension int, options *CreateCollectionOptions, opts ...CallOption) (CollectionResponse, error) {
+	if options == nil {
+		options = &CreateCollectionOptions{}
+	}
+	body := map[string]any{
+		"name":          name,
+		"dimension":     dimension,
+		"strict_finite": options.StrictFinite,
+	}
+	if options.DefaultMetric != "" {
+		if err := validateMetric(options.DefaultMetric); err != nil {
+			return CollectionResponse{}, err
+		}
+		body["default_metric"] = options.DefaultMetric
+	}
+	trimmed := strings.TrimSpace(name)
+	c.invalidateMetadata(ctx, trimmed, opts)
+	var response CollectionResponse
+	if err := c.requestJSON(ctx, http.MethodPost, "/collections", body, &response, opts...); err != nil {
+		return response, err
+	}
+	if response.DefaultMetric == "" {
+		response.DefaultMetric = options.DefaultMetric
+	}
+	if scope, scoped := c.metadataScope(ctx, opts); scoped {
+		c.metadata.put(scope, trimmed, response)
+	}
+	return response, nil
+}
+
+// applyCollectionDefaults fills in what a search body can only know from the
+// collection: its recorded default metric and the mode SearchModeAdaptive
+// resolves to.
+func (c *Client) applyCollectionDefaults(ctx context.Context, collection string, body map[string]any, options *SearchOptions, opts []CallOption) error {
+	if (options == nil || options.Metric == "") && c.defaultMetric == "" {
+		scope, _ := c.metadataScope(ctx, opts)
+		if metric := c.metadata.defaultMetric(scope, strings.TrimSpace(collection)); metric != "" {
+			body["metric"] = metric
+		}
+	}
+	return c.resolveAdaptiveMode(ctx, collection, body, opts)
+}
@@ -0,0 +1,71 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestSearchUsesCollectionDefaultMetric(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var metrics []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if request.URL.Path == "/collections" {
+			if body["default_metric"] != "l2" {
+				t.Errorf("expected default_metric in create body, got %v", body)
+			}
+			writeJSON(t, writer, map[string]any{"name": body["name"], "dimension": 2, "strict_finite": false})
+			return
+		}
+		mu.Lock()
+		metrics = append(metrics, body["metric"].(string))
+		mu.Unlock()
+		writeJSON(t, writer, map[string]any{"metric": body["metric"], "mode": "exact", "hits": []map[string]any{}})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.URL, nil)
+	created, err := client.CreateCollectionWithOptions(ctx, "demo", 2, &CreateCollectionOptions{DefaultMetric: MetricL2})
+	if err != nil {
+		t.Fatalf("CreateCollectionWithOptions failed: %v", err)
+	}
+	if created.DefaultMetric != MetricL2 {
+		t.Fatalf("expected the recorded default metric, got %q", created.DefaultMetric)
+	}
+	query := []float32{1, 2}
+	if _, err := client.SearchCollectionTopK(ctx, "demo", query, nil); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if _, err := client.SearchCollectionTopK(ctx, "demo", query, &SearchTopKOptions{SearchOptions: SearchOptions{Metric: MetricCosine}}); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if _, err := client.SearchCollectionTopK(ctx, "other", query, nil); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	// Another tenant's collection of the same name does not inherit the metric.
+	if _, err := client.SearchCollectionTopK(ctx, "demo", query, nil, WithHeader("X-Tenant", "b")); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"l2", "cosine", "dot", "dot"}
+	if len(metrics) != len(want) {
+		t.Fatalf("expected metrics %v, got %v", want, metrics)
+	}
+	for index := range want {
+		if metrics[index] != want[index] {
+			t.Fatalf("expected metrics %v, got %v", want, metrics)
+		}
+	}
+}
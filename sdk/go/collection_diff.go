@@ -0,0 +1,46 @@
+package aionbd
+
+import "context"
+
+// CollectionFieldDiff is one configuration field that differs between two
+// collections; A and B hold the respective values.
+type CollectionFieldDiff struct {
+	Field string
+	A     any
+	B     any
+}
+
+type CollectionDiff struct {
+	A           CollectionResponse
+	B           CollectionResponse
+	Differences []CollectionFieldDiff
+}
+
+func (d CollectionDiff) Identical() bool {
+	return len(d.Differences) == 0
+}
+
+// DiffCollections fetches collections a and b and reports differences in
+// dimension, strict_finite, and point count. Names are not compared.
+func (c *Client) DiffCollections(ctx context.Context, a, b string, opts ...CallOption) (CollectionDiff, error) {
+	first, err := c.GetCollection(ctx, a, opts...)
+	if err != nil {
+		return CollectionDiff{}, err
+	}
+	second, err := c.GetCollection(ctx, b, opts...)
+	if err != nil {
+		return CollectionDiff{}, err
+	}
+
+	diff := CollectionDiff{A: first, B: second}
+	if first.Dimension != second.Dimension {
+		diff.Differences = append(diff.Differences, CollectionFieldDiff{Field: "dimension", A: first.Dimension, B: second.Dimension})
+	}
+	if first.StrictFinite != second.StrictFinite {
+		diff.Differences = append(diff.Differences, CollectionFieldDiff{Field: "strict_finite", A: first.StrictFinite, B: second.StrictFinite})
+	}
+	if first.PointCount != second.PointCount {
+		diff.Differences = append(diff.Differences, CollectionFieldDiff{Field: "point_count", A: first.PointCount, B: second.PointCount})
+	}
+	return diff, nil
+}
@@ -0,0 +1,55 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiffCollectionsReportsDifferences(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/collections/source":
+			writeJSON(t, writer, map[string]any{"name": "source", "dimension": 384, "strict_finite": true, "point_count": 10})
+		case "/collections/target":
+			writeJSON(t, writer, map[string]any{"name": "target", "dimension": 384, "strict_finite": false, "point_count": 7})
+		case "/collections/copy":
+			writeJSON(t, writer, map[string]any{"name": "copy", "dimension": 384, "strict_finite": true, "point_count": 10})
+		default:
+			t.Fatalf("unexpected path: %s", request.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	diff, err := client.DiffCollections(context.Background(), "source", "target")
+	if err != nil {
+		t.Fatalf("diff collections failed: %v", err)
+	}
+	if diff.Identical() {
+		t.Fatal("expected collections to differ")
+	}
+	expected := []CollectionFieldDiff{
+		{Field: "strict_finite", A: true, B: false},
+		{Field: "point_count", A: 10, B: 7},
+	}
+	if len(diff.Differences) != len(expected) {
+		t.Fatalf("unexpected differences: %#v", diff.Differences)
+	}
+	for index, want := range expected {
+		if diff.Differences[index] != want {
+			t.Fatalf("unexpected difference %d: %#v", index, diff.Differences[index])
+		}
+	}
+
+	same, err := client.DiffCollections(context.Background(), "source", "copy")
+	if err != nil {
+		t.Fatalf("diff identical collections failed: %v", err)
+	}
+	if !same.Identical() {
+		t.Fatalf("expected identical collections, got: %#v", same.Differences)
+	}
+}
@@ -0,0 +1,70 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const defaultWarmupQueries = 8
+
+// EnsureCollection creates the collection unless it already exists and
+// reports whether this call created it. An existing collection with another
+// dimension is an error; its strict_finite setting is left as it is.
+func (c *Client) EnsureCollection(ctx context.Context, name string, dimension int, strictFinite bool, opts ...CallOption) (CollectionResponse, bool, error) {
+	existing, err := c.GetCollection(ctx, name, opts...)
+	if isStatus(err, http.StatusNotFound) {
+		created, createErr := c.CreateCollection(ctx, name, dimension, strictFinite, opts...)
+		if !isStatus(createErr, http.StatusConflict) {
+			return created, createErr == nil, createErr
+		}
+		// Another writer created it in the meantime.
+		existing, err = c.GetCollection(ctx, name, opts...)
+	}
+	if err != nil {
+		return CollectionResponse{}, false, err
+	}
+	if existing.Dimension != dimension {
+		return existing, false, fmt.Errorf("collection %q exists with dimension %d, expected %d", strings.TrimSpace(name), existing.Dimension, dimension)
+	}
+	return existing, false, nil
+}
+
+// WarmupCollection runs up to queries searches (default 8) against the
+// collection, each using the vector of one of its first points, so indexes
+// and caches are loaded before real traffic arrives. It bypasses the client
+// caches and returns the number of searches run; an empty collection runs
+// none. The server has no dedicated warmup endpoint.
+func (c *Client) WarmupCollection(ctx context.Context, collection string, queries int, opts ...CallOption) (int, error) {
+	if queries <= 0 {
+		queries = defaultWarmupQueries
+	}
+	page, err := c.ListPoints(ctx, collection, &ListPointsOptions{Limit: IntPtr(queries)}, opts...)
+	if err != nil {
+		return 0, err
+	}
+	opts = append(opts[:len(opts):len(opts)], WithNoCache())
+	warmed := 0
+	for _, point := range page.Points {
+		if _, err := c.SearchByPointID(ctx, collection, point.ID, &SearchTopKOptions{Limit: IntPtr(1)}, opts...); err != nil {
+			return warmed, err
+		}
+		warmed++
+	}
+	return warmed, nil
+}
+
+// SetCollectionAlias points alias at collection with
+// PUT /aliases/{alias} {"collection": ...}. The AIONBD server does not serve
+// aliases yet, so this needs a server or gateway that does; others answer 404.
+func (c *Client) SetCollectionAlias(ctx context.Context, alias string, collection string, opts ...CallOption) error {
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return fmt.Errorf("alias must not be empty")
+	}
+	path := fmt.Sprintf("/aliases/%s", collectionPathSegment(alias))
+	body := map[string]any{"collection": strings.TrimSpace(collection)}
+	_, err := c.requestJSONOrEmpty(ctx, http.MethodPut, path, body, &struct{}{}, opts...)
+	return err
+}
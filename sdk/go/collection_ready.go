@@ -0,0 +1,28 @@
+package aionbd
+
+import "context"
+
+// CollectionReady reports whether a collection can serve searches, with a
+// reason when it cannot: the collection must hold points, and the server must
+// be ready with no L2 index build in flight. The server only reports index
+// builds globally, so a build for another collection also counts as not ready.
+func (c *Client) CollectionReady(ctx context.Context, collection string) (bool, string, error) {
+	info, err := c.GetCollection(ctx, collection)
+	if err != nil {
+		return false, "", err
+	}
+	if info.PointCount == 0 {
+		return false, "collection has no points", nil
+	}
+	metrics, err := c.Metrics(ctx)
+	if err != nil {
+		return false, "", err
+	}
+	if !metrics.Ready {
+		return false, "server is not ready", nil
+	}
+	if metrics.L2IndexBuildInFlight > 0 {
+		return false, "index build in progress", nil
+	}
+	return true, "", nil
+}
@@ -0,0 +1,43 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollectionReadyReportsReason(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/collections/empty":
+			writeJSON(t, writer, map[string]any{"name": "empty", "dimension": 2, "point_count": 0})
+		case "/collections/filled":
+			writeJSON(t, writer, map[string]any{"name": "filled", "dimension": 2, "point_count": 10})
+		case "/metrics":
+			writeJSON(t, writer, map[string]any{"ready": true, "l2_index_build_in_flight": 0})
+		default:
+			t.Errorf("unexpected path %s", request.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ready, reason, err := client.CollectionReady(context.Background(), "empty")
+	if err != nil {
+		t.Fatalf("CollectionReady returned error: %v", err)
+	}
+	if ready || reason != "collection has no points" {
+		t.Fatalf("expected empty collection not ready, got ready=%v reason=%q", ready, reason)
+	}
+
+	ready, reason, err = client.CollectionReady(context.Background(), "filled")
+	if err != nil {
+		t.Fatalf("CollectionReady returned error: %v", err)
+	}
+	if !ready || reason != "" {
+		t.Fatalf("expected filled collection ready, got ready=%v reason=%q", ready, reason)
+	}
+}
@@ -0,0 +1,123 @@
+package aionbd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+type CompressionAlgorithm string
+
+const (
+	CompressionNone CompressionAlgorithm = ""
+	CompressionGzip CompressionAlgorithm = "gzip"
+	CompressionZstd CompressionAlgorithm = "zstd"
+)
+
+func validateCompression(algorithm CompressionAlgorithm) error {
+	switch algorithm {
+	case CompressionNone, CompressionGzip, CompressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression algorithm %q", algorithm)
+	}
+}
+
+// minCompressionGain is the fraction of the original size a compressed body
+// must save before it is sent compressed; smaller wins are not worth the
+// server-side CPU.
+const minCompressionGain = 0.10
+
+// The zstd encoder and decoder are safe for concurrent EncodeAll/DecodeAll
+// calls and costly to build, so every client shares one of each.
+var (
+	zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil)
+	})
+	zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	})
+)
+
+// compressBody returns the bytes to send and their Content-Encoding. Bodies
+// that do not shrink by at least minCompressionGain are sent uncompressed, as
+// are zstd bodies once the server has answered one with 415.
+func (c *Client) compressBody(body []byte) ([]byte, string, error) {
+	if len(body) == 0 {
+		return body, "", nil
+	}
+
+	var compressed []byte
+	switch c.compression {
+	case CompressionGzip:
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		if _, err := writer.Write(body); err != nil {
+			return nil, "", fmt.Errorf("gzip request body: %w", err)
+		}
+		if err := writer.Close(); err != nil {
+			return nil, "", fmt.Errorf("gzip request body: %w", err)
+		}
+		compressed = buffer.Bytes()
+	case CompressionZstd:
+		if !c.capabilities.supported(capabilityZstdRequests) {
+			return body, "", nil
+		}
+		encoder, err := zstdEncoder()
+		if err != nil {
+			return nil, "", fmt.Errorf("zstd request body: %w", err)
+		}
+		compressed = encoder.EncodeAll(body, nil)
+	default:
+		return body, "", nil
+	}
+	if float64(len(compressed)) > float64(len(body))*(1-minCompressionGain) {
+		return body, "", nil
+	}
+	return compressed, string(c.compression), nil
+}
+
+// acceptEncoding is the Accept-Encoding sent with zstd compression. Setting it
+// turns off the transport's transparent gzip handling, so decodeResponseBody
+// handles both codings.
+func (c *Client) acceptEncoding() string {
+	if c.compression == CompressionZstd {
+		return "zstd, gzip"
+	}
+	return ""
+}
+
+func decodeResponseBody(contentEncoding string, body []byte) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip":
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("decode gzip response: %w", err)
+		}
+		defer reader.Close()
+		decoded, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("decode gzip response: %w", err)
+		}
+		return decoded, nil
+	case "zstd":
+		decoder, err := zstdDecoder()
+		if err != nil {
+			return nil, fmt.Errorf("decode zstd response: %w", err)
+		}
+		decoded, err := decoder.DecodeAll(body, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decode zstd response: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unsupported response Content-Encoding %q", contentEncoding)
+	}
+}
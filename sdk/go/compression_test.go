@@ -0,0 +1,143 @@
+package aionbd
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestCompressionGzipsOnlyWhenBeneficial(t *testing.T) {
+	t.Parallel()
+
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		encodings = append(encodings, request.Header.Get("Content-Encoding"))
+		var reader io.Reader = request.Body
+		if request.Header.Get("Content-Encoding") == "gzip" {
+			gzipReader, err := gzip.NewReader(request.Body)
+			if err != nil {
+				t.Fatalf("open gzip body: %v", err)
+			}
+			reader = gzipReader
+		}
+		if _, err := io.ReadAll(reader); err != nil {
+			t.Fatalf("read body: %v", err)
+		}
+		writeJSON(t, writer, map[string]any{"id": 1, "created": true})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{Compression: CompressionGzip})
+
+	compressible := make([]float32, 2048)
+	if _, err := client.UpsertPoint(context.Background(), "demo", 1, compressible, nil); err != nil {
+		t.Fatalf("compressible upsert failed: %v", err)
+	}
+
+	random := rand.New(rand.NewSource(7))
+	noisy := make([]float32, 4)
+	for index := range noisy {
+		noisy[index] = random.Float32()
+	}
+	if _, err := client.UpsertPoint(context.Background(), "demo", 1, noisy, nil); err != nil {
+		t.Fatalf("tiny upsert failed: %v", err)
+	}
+
+	if len(encodings) != 2 || encodings[0] != "gzip" || encodings[1] != "" {
+		t.Fatalf("unexpected content encodings: %#v", encodings)
+	}
+}
+
+func TestUnsupportedCompressionIsConfigError(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("http://127.0.0.1:1", &ClientOptions{Compression: "brotli"})
+	err := client.ConfigError()
+	if err == nil || !strings.Contains(err.Error(), "unsupported compression") {
+		t.Fatalf("expected unsupported compression error, got: %v", err)
+	}
+}
+
+func TestCompressionZstdRoundTripsBatch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if got := request.Header.Get("Content-Encoding"); got != "zstd" {
+			t.Errorf("expected zstd request body, got %q", got)
+		}
+		if got := request.Header.Get("Accept-Encoding"); !strings.Contains(got, "zstd") {
+			t.Errorf("expected zstd in Accept-Encoding, got %q", got)
+		}
+		decoder, err := zstd.NewReader(request.Body)
+		if err != nil {
+			t.Fatalf("open zstd body: %v", err)
+		}
+		defer decoder.Close()
+		var body struct {
+			Points []UpsertPointsBatchItem `json:"points"`
+		}
+		if err := json.NewDecoder(decoder).Decode(&body); err != nil {
+			t.Fatalf("decode zstd body: %v", err)
+		}
+
+		response, err := json.Marshal(map[string]any{"created": len(body.Points), "updated": 0, "results": []any{}})
+		if err != nil {
+			t.Fatalf("encode response: %v", err)
+		}
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			t.Fatalf("create zstd encoder: %v", err)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("Content-Encoding", "zstd")
+		_, _ = writer.Write(encoder.EncodeAll(response, nil))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{Compression: CompressionZstd})
+	points := make([]UpsertPointsBatchItem, 64)
+	for index := range points {
+		points[index] = UpsertPointsBatchItem{ID: uint64(index), Values: make([]float32, 128)}
+	}
+	response, err := client.UpsertPointsBatch(context.Background(), "demo", points)
+	if err != nil {
+		t.Fatalf("UpsertPointsBatch returned error: %v", err)
+	}
+	if response.Created != len(points) {
+		t.Fatalf("expected %d created, got %+v", len(points), response)
+	}
+}
+
+func TestCompressionZstdFallsBackOnUnsupportedMediaType(t *testing.T) {
+	t.Parallel()
+
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		encodings = append(encodings, request.Header.Get("Content-Encoding"))
+		if request.Header.Get("Content-Encoding") == "zstd" {
+			http.Error(writer, "unsupported content encoding", http.StatusUnsupportedMediaType)
+			return
+		}
+		writeJSON(t, writer, map[string]any{"id": 1, "created": true})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{Compression: CompressionZstd})
+	values := make([]float32, 2048)
+	for range 2 {
+		if _, err := client.UpsertPoint(context.Background(), "demo", 1, values, nil); err != nil {
+			t.Fatalf("UpsertPoint returned error: %v", err)
+		}
+	}
+	if len(encodings) != 3 || encodings[0] != "zstd" || encodings[1] != "" || encodings[2] != "" {
+		t.Fatalf("unexpected content encodings: %#v", encodings)
+	}
+}
@@ -0,0 +1,135 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeStore struct {
+	mu          sync.Mutex
+	collections map[string]int
+	points      map[string]map[uint64][]float32
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		collections: make(map[string]int),
+		points:      make(map[string]map[uint64][]float32),
+	}
+}
+
+func (store *fakeStore) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && request.Method == http.MethodPost:
+		var body struct {
+			Name      string `json:"name"`
+			Dimension int    `json:"dimension"`
+		}
+		_ = json.NewDecoder(request.Body).Decode(&body)
+		store.collections[body.Name] = body.Dimension
+		store.points[body.Name] = make(map[uint64][]float32)
+		writeStoreJSON(writer, map[string]any{"name": body.Name, "dimension": body.Dimension})
+	case len(parts) == 2 && request.Method == http.MethodGet:
+		dimension, found := store.collections[parts[1]]
+		if !found {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeStoreJSON(writer, map[string]any{
+			"name":        parts[1],
+			"dimension":   dimension,
+			"point_count": len(store.points[parts[1]]),
+		})
+	case len(parts) == 4 && parts[2] == "points" && request.Method == http.MethodPut:
+		id, _ := strconv.ParseUint(parts[3], 10, 64)
+		var body struct {
+			Values []float32 `json:"values"`
+		}
+		_ = json.NewDecoder(request.Body).Decode(&body)
+		_, existed := store.points[parts[1]][id]
+		store.points[parts[1]][id] = body.Values
+		writeStoreJSON(writer, map[string]any{"id": id, "created": !existed})
+	case len(parts) == 4 && parts[3] == "topk":
+		// Ignores exclude_ids so the client exercises its shared fallback state.
+		hits := []map[string]any{}
+		for id := range store.points[parts[1]] {
+			hits = append(hits, map[string]any{"id": id, "value": 1})
+		}
+		writeStoreJSON(writer, map[string]any{"metric": "dot", "mode": "exact", "hits": hits})
+	default:
+		writer.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestClientConcurrentMixedOperations(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(newFakeStore())
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ctx := context.Background()
+	const collections = 4
+	for index := 0; index < collections; index++ {
+		if _, err := client.CreateCollection(ctx, fmt.Sprintf("c%d", index), 2, true); err != nil {
+			t.Fatalf("create collection failed: %v", err)
+		}
+	}
+
+	const workers = 200
+	errs := make(chan error, workers)
+	var wait sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wait.Add(1)
+		go func(worker int) {
+			defer wait.Done()
+			name := fmt.Sprintf("c%d", worker%collections)
+			id := uint64(worker)
+			if _, err := client.UpsertPoint(ctx, name, id, []float32{1, 0}, nil); err != nil {
+				errs <- err
+				return
+			}
+			collection, err := client.GetCollection(ctx, name)
+			if err != nil || collection.Dimension != 2 {
+				errs <- fmt.Errorf("get collection %s: %v (%#v)", name, err, collection)
+				return
+			}
+			response, err := client.SearchCollectionTopK(ctx, name, []float32{1, 0}, &SearchTopKOptions{
+				SearchOptions: SearchOptions{ExcludeIDs: []uint64{id}},
+				Limit:         IntPtr(workers),
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if hitsLeakExcluded(response.Hits, map[uint64]struct{}{id: {}}) {
+				errs <- fmt.Errorf("worker %d saw its excluded id", worker)
+			}
+		}(worker)
+	}
+	wait.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if client.capabilities.supported(capabilityExcludeIDs) {
+		t.Fatal("expected exclude_ids to be recorded as unsupported")
+	}
+}
+
+func writeStoreJSON(writer http.ResponseWriter, payload map[string]any) {
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(payload)
+}
@@ -0,0 +1,187 @@
+package aionbd
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConsistentRead asks the server, via the X-Consistent-Read header, to answer
+// from its latest state and bypasses the search cache. Servers that ignore the
+// header may serve a search from an index that has not caught up with a write
+// yet, so the client also remembers its last writes (the 256 most recent
+// points, for 2s) and, when one of them is missing from the hits although its
+// locally computed score would rank it among them, re-issues the search with a
+// short backoff until it shows up or the write leaves that window. This
+// fallback is best-effort: it only sees this client's own writes, is skipped
+// for filtered searches, and gives up silently, returning the last response.
+// It applies to SearchCollection and SearchCollectionTopK; on a pooled client
+// the attempts share a session, so they stay on one node.
+
+const (
+	consistentReadHeader = "X-Consistent-Read"
+
+	recentWriteLimit           = 256
+	recentWriteWindow          = 2 * time.Second
+	consistentReadInitialDelay = 20 * time.Millisecond
+)
+
+type recentWrite struct {
+	collection string
+	id         uint64
+	values     []float32
+	at         time.Time
+}
+
+// recentWrites is a ring of the last points written through the client.
+type recentWrites struct {
+	mu      sync.Mutex
+	entries []recentWrite
+	next    int
+}
+
+func newRecentWrites() *recentWrites {
+	return &recentWrites{entries: make([]recentWrite, 0, recentWriteLimit)}
+}
+
+func (writes *recentWrites) record(collection string, id uint64, values []float32, now time.Time) {
+	writes.mu.Lock()
+	defer writes.mu.Unlock()
+	write := recentWrite{collection: collection, id: id, values: slices.Clone(values), at: now}
+	if len(writes.entries) < recentWriteLimit {
+		writes.entries = append(writes.entries, write)
+		return
+	}
+	writes.entries[writes.next] = write
+	writes.next = (writes.next + 1) % recentWriteLimit
+}
+
+func (writes *recentWrites) recordItems(collection string, points []UpsertPointsBatchItem, now time.Time) {
+	// Only the tail of a large batch can survive in the ring.
+	for _, point := range points[max(0, len(points)-recentWriteLimit):] {
+		writes.record(collection, point.ID, point.Values, now)
+	}
+}
+
+func (writes *recentWrites) forget(collection string, ids ...uint64) {
+	writes.mu.Lock()
+	defer writes.mu.Unlock()
+	for index := range writes.entries {
+		entry := &writes.entries[index]
+		if entry.collection == collection && (len(ids) == 0 || slices.Contains(ids, entry.id)) {
+			*entry = recentWrite{}
+		}
+	}
+}
+
+// pending returns the latest write per point of collection within the window.
+func (writes *recentWrites) pending(collection string, now time.Time) []recentWrite {
+	writes.mu.Lock()
+	defer writes.mu.Unlock()
+	latest := make(map[uint64]recentWrite)
+	for _, entry := range writes.entries {
+		if entry.collection != collection || now.Sub(entry.at) > recentWriteWindow {
+			continue
+		}
+		if previous, found := latest[entry.id]; !found || entry.at.After(previous.at) {
+			latest[entry.id] = entry
+		}
+	}
+	pending := make([]recentWrite, 0, len(latest))
+	for _, entry := range latest {
+		pending = append(pending, entry)
+	}
+	return pending
+}
+
+// missingRecentWrite reports whether a pending write of collection should rank
+// among hits but is absent from them.
+func (c *Client) missingRecentWrite(collection string, query []float32, options *SearchOptions, metric Metric, hits []SearchHit, limit int) bool {
+	if options.Filter != nil {
+		return false
+	}
+	excluded := excludedIDSet(options)
+	for _, write := range c.recentWrites.pending(strings.TrimSpace(collection), time.Now()) {
+		if isExcluded(excluded, write.id) || slices.ContainsFunc(hits, func(hit SearchHit) bool { return hit.ID == write.id }) {
+			continue
+		}
+		if len(hits) < limit {
+			return true
+		}
+		score, err := Compute(query, write.values, metric)
+		if err != nil {
+			continue
+		}
+		worst := worstHitValue(hits, metric)
+		if metric == MetricL2 && score < worst || metric != MetricL2 && score > worst {
+			return true
+		}
+	}
+	return false
+}
+
+func worstHitValue(hits []SearchHit, metric Metric) float32 {
+	worst := hits[0].Value
+	for _, hit := range hits[1:] {
+		if metric == MetricL2 && hit.Value > worst || metric != MetricL2 && hit.Value < worst {
+			worst = hit.Value
+		}
+	}
+	return worst
+}
+
+// untilWritesVisible runs search until no recent write is missing from its
+// hits, backing off between attempts.
+func (c *Client) untilWritesVisible(ctx context.Context, collection string, query []float32, options *SearchOptions, limit int, search func() ([]SearchHit, Metric, error)) error {
+	delay := consistentReadInitialDelay
+	for {
+		hits, metric, err := search()
+		if err != nil {
+			return err
+		}
+		if metric == "" {
+			metric = c.withMetricDefault(options.Metric)
+		}
+		if !c.missingRecentWrite(collection, query, options, metric, hits, limit) {
+			return nil
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+		delay *= 2
+	}
+}
+
+func consistentReadOpts(opts []CallOption) []CallOption {
+	return append(opts[:len(opts):len(opts)], withRequestHeader(consistentReadHeader, "true"), WithNoCache())
+}
+
+func (c *Client) consistentSearch(ctx context.Context, collection string, query []float32, options *SearchOptions, opts []CallOption) (SearchResponse, error) {
+	plain := *options
+	plain.ConsistentRead = false
+	ctx = WithSession(ctx)
+	opts = consistentReadOpts(opts)
+	var response SearchResponse
+	err := c.untilWritesVisible(ctx, collection, query, &plain, 1, func() ([]SearchHit, Metric, error) {
+		var err error
+		response, err = c.searchCollection(ctx, collection, query, &plain, opts)
+		return []SearchHit{{ID: response.ID, Value: response.Value}}, response.Metric, err
+	})
+	return response, err
+}
+
+func (c *Client) consistentSearchTopK(ctx context.Context, collection string, query []float32, options *SearchTopKOptions, limit int, opts []CallOption) (SearchTopKResponse, error) {
+	plain := *options
+	plain.ConsistentRead = false
+	ctx = WithSession(ctx)
+	opts = consistentReadOpts(opts)
+	var response SearchTopKResponse
+	err := c.untilWritesVisible(ctx, collection, query, &plain.SearchOptions, limit, func() ([]SearchHit, Metric, error) {
+		var err error
+		response, err = c.searchCollectionTopK(ctx, collection, query, &plain, opts)
+		return response.Hits, response.Metric, err
+	})
+	return response, err
+}
@@ -0,0 +1,73 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestConsistentReadRetriesUntilRecentWriteIsVisible(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	searches := 0
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPut {
+			writeJSON(t, writer, map[string]any{"id": 42, "created": true})
+			return
+		}
+		mu.Lock()
+		searches++
+		visible := searches >= 3
+		headers = append(headers, request.Header.Get("X-Consistent-Read"))
+		mu.Unlock()
+
+		hits := []map[string]any{{"id": 1, "value": 0.5}, {"id": 2, "value": 0.25}}
+		if visible {
+			hits = []map[string]any{{"id": 42, "value": 1}, {"id": 1, "value": 0.5}}
+		}
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": hits})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{SearchCache: &SearchCacheConfig{}})
+	ctx := context.Background()
+	if _, err := client.UpsertPoint(ctx, "demo", 42, []float32{1, 0}, nil); err != nil {
+		t.Fatalf("UpsertPoint returned error: %v", err)
+	}
+
+	options := &SearchTopKOptions{SearchOptions: SearchOptions{Metric: MetricDot, ConsistentRead: true}, Limit: IntPtr(2)}
+	response, err := client.SearchCollectionTopK(ctx, "demo", []float32{1, 0}, options)
+	if err != nil {
+		t.Fatalf("SearchCollectionTopK returned error: %v", err)
+	}
+	if len(response.Hits) == 0 || response.Hits[0].ID != 42 {
+		t.Fatalf("expected the upserted point to surface, got %+v", response.Hits)
+	}
+	mu.Lock()
+	if searches != 3 {
+		t.Fatalf("expected 3 searches, got %d", searches)
+	}
+	for _, header := range headers {
+		if header != "true" {
+			t.Fatalf("expected the consistent-read header on every attempt, got %q", headers)
+		}
+	}
+	mu.Unlock()
+
+	// A recent write that would not rank among the hits causes no retry.
+	if _, err := client.UpsertPoint(ctx, "demo", 43, []float32{0, 1}, nil); err != nil {
+		t.Fatalf("UpsertPoint returned error: %v", err)
+	}
+	if _, err := client.SearchCollectionTopK(ctx, "demo", []float32{1, 0}, options); err != nil {
+		t.Fatalf("SearchCollectionTopK returned error: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if searches != 4 {
+		t.Fatalf("expected a single search for an irrelevant write, got %d total", searches)
+	}
+}
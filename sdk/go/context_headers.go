@@ -0,0 +1,28 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextHeadersKey struct{}
+
+// WithContextHeaders returns a context whose requests carry headers h, e.g. a
+// tenant or trace ID propagated by middleware. Headers from an outer
+// WithContextHeaders are kept unless h overrides them. They have the lowest
+// precedence: client default headers and WithHeader call options win.
+func WithContextHeaders(ctx context.Context, h map[string]string) context.Context {
+	merged := make(map[string]string, len(h))
+	for key, value := range contextHeaders(ctx) {
+		merged[key] = value
+	}
+	for key, value := range h {
+		merged[http.CanonicalHeaderKey(key)] = value
+	}
+	return context.WithValue(ctx, contextHeadersKey{}, merged)
+}
+
+func contextHeaders(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(contextHeadersKey{}).(map[string]string)
+	return headers
+}
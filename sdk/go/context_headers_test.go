@@ -0,0 +1,41 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContextHeadersHaveLowestPrecedence(t *testing.T) {
+	t.Parallel()
+
+	var captured []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		captured = append(captured, request.Header.Clone())
+		writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 1})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{Headers: map[string]string{"X-Region": "eu"}})
+	ctx := WithContextHeaders(context.Background(), map[string]string{"x-tenant": "acme", "X-Region": "us"})
+	ctx = WithContextHeaders(ctx, map[string]string{"X-Trace": "abc"})
+
+	if _, err := client.Live(ctx); err != nil {
+		t.Fatalf("live failed: %v", err)
+	}
+	if _, err := client.Live(ctx, WithHeader("X-Tenant", "override")); err != nil {
+		t.Fatalf("live with header option failed: %v", err)
+	}
+
+	first := captured[0]
+	if first.Get("X-Tenant") != "acme" || first.Get("X-Trace") != "abc" {
+		t.Fatalf("context headers missing: %#v", first)
+	}
+	if first.Get("X-Region") != "eu" {
+		t.Fatalf("expected default header to override context header, got: %q", first.Get("X-Region"))
+	}
+	if got := captured[1].Get("X-Tenant"); got != "override" {
+		t.Fatalf("expected WithHeader to override context header, got: %q", got)
+	}
+}
@@ -0,0 +1,103 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type CopyStats struct {
+	Copied int
+	Failed int
+}
+
+// CopyPoints copies every point of src (values and payload) into dst. IDs are
+// listed page by page and each page of batchSize points is read with
+// GetPointsBatch and upserted into dst by one of concurrency workers. Both
+// collections must have the same dimension. A failed batch counts its points
+// as Failed and the copy goes on; every failure is returned joined into one
+// error alongside the stats. Points deleted from src during the copy are
+// skipped without counting as failures.
+func (c *Client) CopyPoints(ctx context.Context, src, dst string, batchSize, concurrency int) (stats CopyStats, err error) {
+	if batchSize <= 0 {
+		return CopyStats{}, fmt.Errorf("batchSize must be a positive integer")
+	}
+	if concurrency <= 0 {
+		return CopyStats{}, fmt.Errorf("concurrency must be a positive integer")
+	}
+	ctx, finish := c.withOperationTimeout(withServerThrottle(ctx))
+	defer func() { err = finish(err) }()
+
+	srcDimension, err := c.Dimension(ctx, src)
+	if err != nil {
+		return CopyStats{}, err
+	}
+	dstDimension, err := c.Dimension(ctx, dst)
+	if err != nil {
+		return CopyStats{}, err
+	}
+	if srcDimension != dstDimension {
+		return CopyStats{}, fmt.Errorf("dimension mismatch: %s has %d, %s has %d", src, srcDimension, dst, dstDimension)
+	}
+
+	var mu sync.Mutex
+	var failures []error
+	batches := make(chan []uint64)
+	var workers sync.WaitGroup
+	for range concurrency {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for ids := range batches {
+				copied, err := c.copyBatch(ctx, src, dst, ids)
+				mu.Lock()
+				stats.Copied += copied
+				if err != nil {
+					stats.Failed += len(ids)
+					failures = append(failures, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	iterator := c.IteratePoints(ctx, src, &ListPointsOptions{Limit: IntPtr(batchSize)})
+	defer iterator.Close()
+	batch := make([]uint64, 0, batchSize)
+	for iterator.Next() {
+		batch = append(batch, iterator.Point().ID)
+		if len(batch) == batchSize {
+			batches <- batch
+			batch = make([]uint64, 0, batchSize)
+		}
+	}
+	if len(batch) > 0 {
+		batches <- batch
+	}
+	close(batches)
+	workers.Wait()
+
+	if err := iterator.Err(); err != nil {
+		failures = append(failures, err)
+	}
+	return stats, errors.Join(failures...)
+}
+
+func (c *Client) copyBatch(ctx context.Context, src, dst string, ids []uint64) (int, error) {
+	points, err := c.GetPointsBatch(ctx, src, ids, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(points) == 0 {
+		return 0, nil
+	}
+	items := make([]UpsertPointsBatchItem, len(points))
+	for index, point := range points {
+		items[index] = UpsertPointsBatchItem{ID: point.ID, Values: point.Values, Payload: point.Payload}
+	}
+	if _, err := c.UpsertPointsBatch(ctx, dst, items); err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
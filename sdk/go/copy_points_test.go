@@ -0,0 +1,89 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestCopyPointsCopiesValuesAndPayloads(t *testing.T) {
+	t.Parallel()
+
+	source := map[uint64]PointResponse{}
+	for id := uint64(1); id <= 5; id++ {
+		source[id] = PointResponse{ID: id, Values: []float32{float32(id), 0}, Payload: PointPayload{"label": float64(id)}}
+	}
+	var mu sync.Mutex
+	copied := map[uint64]UpsertPointsBatchItem{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.Method + " " + request.URL.Path {
+		case "GET /collections/src", "GET /collections/dst":
+			writeJSON(t, writer, map[string]any{"name": "x", "dimension": 2})
+		case "GET /collections/src/points":
+			writeJSON(t, writer, map[string]any{"points": []map[string]any{{"id": 1}, {"id": 2}, {"id": 3}, {"id": 4}, {"id": 5}}})
+		case "POST /collections/src/points/get":
+			var body struct {
+				IDs []uint64 `json:"ids"`
+			}
+			if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+				t.Errorf("decode get body: %v", err)
+			}
+			points := make([]PointResponse, 0, len(body.IDs))
+			for _, id := range body.IDs {
+				points = append(points, source[id])
+			}
+			writeJSON(t, writer, map[string]any{"points": points})
+		case "POST /collections/dst/points":
+			var body struct {
+				Points []UpsertPointsBatchItem `json:"points"`
+			}
+			if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+				t.Errorf("decode upsert body: %v", err)
+			}
+			mu.Lock()
+			for _, point := range body.Points {
+				copied[point.ID] = point
+			}
+			mu.Unlock()
+			writeJSON(t, writer, map[string]any{"created": len(body.Points), "updated": 0, "results": []any{}})
+		default:
+			t.Errorf("unexpected request %s %s", request.Method, request.URL.Path)
+			http.NotFound(writer, request)
+		}
+	}))
+	defer server.Close()
+
+	stats, err := NewClient(server.URL, nil).CopyPoints(context.Background(), "src", "dst", 2, 2)
+	if err != nil {
+		t.Fatalf("CopyPoints returned error: %v", err)
+	}
+	if stats.Copied != 5 || stats.Failed != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	for id, want := range source {
+		got, ok := copied[id]
+		if !ok || got.Values[0] != want.Values[0] || got.Payload["label"] != want.Payload["label"] {
+			t.Fatalf("point %d not copied faithfully: %+v", id, got)
+		}
+	}
+}
+
+func TestCopyPointsRejectsDimensionMismatch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		dimension := 2
+		if request.URL.Path == "/collections/dst" {
+			dimension = 3
+		}
+		writeJSON(t, writer, map[string]any{"name": "x", "dimension": dimension})
+	}))
+	defer server.Close()
+
+	if _, err := NewClient(server.URL, nil).CopyPoints(context.Background(), "src", "dst", 2, 1); err == nil {
+		t.Fatal("expected a dimension mismatch error")
+	}
+}
@@ -0,0 +1,153 @@
+package aionbd
+
+import (
+	"fmt"
+	"math"
+)
+
+// Compute scores two vectors locally the way the server's /distance endpoint
+// does: dot product, Euclidean distance for l2, and cosine similarity. An
+// empty metric means MetricDot.
+func Compute(left []float32, right []float32, metric Metric) (float32, error) {
+	if metric == "" {
+		metric = MetricDot
+	}
+	if err := validateMetric(metric); err != nil {
+		return 0, err
+	}
+	if err := validatePair(left, right); err != nil {
+		return 0, err
+	}
+
+	var dot, leftNorm, rightNorm, squared float64
+	for index := range left {
+		l, r := float64(left[index]), float64(right[index])
+		dot += l * r
+		leftNorm += l * l
+		rightNorm += r * r
+		squared += (l - r) * (l - r)
+	}
+	switch metric {
+	case MetricL2:
+		return float32(math.Sqrt(squared)), nil
+	case MetricCosine:
+		if leftNorm == 0 || rightNorm == 0 {
+			return 0, fmt.Errorf("cosine is undefined for a zero-norm vector")
+		}
+		return float32(dot / (math.Sqrt(leftNorm) * math.Sqrt(rightNorm))), nil
+	default:
+		return float32(dot), nil
+	}
+}
+
+// DistancesOneToMany scores query against every candidate, returning one score
+// per candidate in order. It validates all lengths up front, then runs an
+// unrolled loop with four independent accumulators over slices resliced to
+// the query length, which lets the compiler drop per-element bounds checks.
+// For cosine the query norm is computed once.
+func DistancesOneToMany(query []float32, candidates [][]float32, metric Metric) ([]float32, error) {
+	if metric == "" {
+		metric = MetricDot
+	}
+	if err := validateMetric(metric); err != nil {
+		return nil, err
+	}
+	if len(query) == 0 {
+		return nil, fmt.Errorf("query must not be empty")
+	}
+	for index, candidate := range candidates {
+		if len(candidate) != len(query) {
+			return nil, fmt.Errorf("candidate %d has dimension %d, expected %d", index, len(candidate), len(query))
+		}
+	}
+
+	scores := make([]float32, len(candidates))
+	var queryNorm float32
+	if metric == MetricCosine {
+		queryNorm = float32(math.Sqrt(float64(dotUnrolled(query, query))))
+		if queryNorm == 0 {
+			return nil, fmt.Errorf("cosine is undefined for a zero-norm query")
+		}
+	}
+	for index, candidate := range candidates {
+		switch metric {
+		case MetricL2:
+			scores[index] = float32(math.Sqrt(float64(l2SquaredUnrolled(query, candidate))))
+		case MetricCosine:
+			dot, norm := dotAndNormUnrolled(query, candidate)
+			if norm == 0 {
+				return nil, fmt.Errorf("cosine is undefined for zero-norm candidate %d", index)
+			}
+			scores[index] = dot / (queryNorm * float32(math.Sqrt(float64(norm))))
+		default:
+			scores[index] = dotUnrolled(query, candidate)
+		}
+	}
+	return scores, nil
+}
+
+func validatePair(left []float32, right []float32) error {
+	if len(left) == 0 || len(right) == 0 {
+		return fmt.Errorf("vectors must not be empty")
+	}
+	if len(left) != len(right) {
+		return fmt.Errorf("dimension mismatch: %d vs %d", len(left), len(right))
+	}
+	return nil
+}
+
+func dotUnrolled(a []float32, b []float32) float32 {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	index := 0
+	for ; index+4 <= len(a); index += 4 {
+		s0 += a[index] * b[index]
+		s1 += a[index+1] * b[index+1]
+		s2 += a[index+2] * b[index+2]
+		s3 += a[index+3] * b[index+3]
+	}
+	for ; index < len(a); index++ {
+		s0 += a[index] * b[index]
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+func l2SquaredUnrolled(a []float32, b []float32) float32 {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	index := 0
+	for ; index+4 <= len(a); index += 4 {
+		d0 := a[index] - b[index]
+		d1 := a[index+1] - b[index+1]
+		d2 := a[index+2] - b[index+2]
+		d3 := a[index+3] - b[index+3]
+		s0 += d0 * d0
+		s1 += d1 * d1
+		s2 += d2 * d2
+		s3 += d3 * d3
+	}
+	for ; index < len(a); index++ {
+		d := a[index] - b[index]
+		s0 += d * d
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+// dotAndNormUnrolled returns the dot product of a and b and the squared norm
+// of b in one pass.
+func dotAndNormUnrolled(a []float32, b []float32) (float32, float32) {
+	b = b[:len(a)]
+	var d0, d1, n0, n1 float32
+	index := 0
+	for ; index+2 <= len(a); index += 2 {
+		d0 += a[index] * b[index]
+		d1 += a[index+1] * b[index+1]
+		n0 += b[index] * b[index]
+		n1 += b[index+1] * b[index+1]
+	}
+	for ; index < len(a); index++ {
+		d0 += a[index] * b[index]
+		n0 += b[index] * b[index]
+	}
+	return d0 + d1, n0 + n1
+}
@@ -0,0 +1,103 @@
+package aionbd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randomVectors(random *rand.Rand, count int, dimension int) [][]float32 {
+	vectors := make([][]float32, count)
+	for index := range vectors {
+		vectors[index] = make([]float32, dimension)
+		for component := range vectors[index] {
+			vectors[index][component] = random.Float32()*2 - 1
+		}
+	}
+	return vectors
+}
+
+func TestDistancesOneToManyMatchesCompute(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewSource(3))
+	query := randomVectors(random, 1, 37)[0]
+	candidates := randomVectors(random, 16, 37)
+	for _, metric := range []Metric{MetricDot, MetricL2, MetricCosine} {
+		scores, err := DistancesOneToMany(query, candidates, metric)
+		if err != nil {
+			t.Fatalf("%s: DistancesOneToMany returned error: %v", metric, err)
+		}
+		for index, candidate := range candidates {
+			want, err := Compute(query, candidate, metric)
+			if err != nil {
+				t.Fatalf("%s: Compute returned error: %v", metric, err)
+			}
+			if math.Abs(float64(scores[index]-want)) > 1e-4 {
+				t.Fatalf("%s: candidate %d scored %v, Compute gives %v", metric, index, scores[index], want)
+			}
+		}
+	}
+}
+
+func TestDistancesOneToManyRejectsMismatchedLengths(t *testing.T) {
+	t.Parallel()
+
+	if _, err := DistancesOneToMany([]float32{1, 2}, [][]float32{{1, 2}, {1}}, MetricDot); err == nil {
+		t.Fatal("expected a dimension error")
+	}
+	if _, err := Compute([]float32{0, 0}, []float32{1, 1}, MetricCosine); err == nil {
+		t.Fatal("expected a zero-norm error")
+	}
+}
+
+// naiveDistances is the baseline for BenchmarkDistancesOneToMany: one
+// accumulator per quantity and a metric switch inside the inner loop.
+func naiveDistances(query []float32, candidates [][]float32, metric Metric) []float32 {
+	scores := make([]float32, len(candidates))
+	for index, candidate := range candidates {
+		var sum, queryNorm, candidateNorm float32
+		for component := range query {
+			switch metric {
+			case MetricL2:
+				difference := query[component] - candidate[component]
+				sum += difference * difference
+			case MetricCosine:
+				sum += query[component] * candidate[component]
+				queryNorm += query[component] * query[component]
+				candidateNorm += candidate[component] * candidate[component]
+			default:
+				sum += query[component] * candidate[component]
+			}
+		}
+		switch metric {
+		case MetricL2:
+			scores[index] = float32(math.Sqrt(float64(sum)))
+		case MetricCosine:
+			scores[index] = sum / float32(math.Sqrt(float64(queryNorm))*math.Sqrt(float64(candidateNorm)))
+		default:
+			scores[index] = sum
+		}
+	}
+	return scores
+}
+
+func BenchmarkDistancesOneToMany(b *testing.B) {
+	random := rand.New(rand.NewSource(5))
+	query := randomVectors(random, 1, 384)[0]
+	candidates := randomVectors(random, 1000, 384)
+	for _, metric := range []Metric{MetricDot, MetricL2, MetricCosine} {
+		b.Run(string(metric)+"/naive", func(b *testing.B) {
+			for range b.N {
+				naiveDistances(query, candidates, metric)
+			}
+		})
+		b.Run(string(metric)+"/unrolled", func(b *testing.B) {
+			for range b.N {
+				if _, err := DistancesOneToMany(query, candidates, metric); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+const exportPageSize = 256
+
+// ExportCollection streams every point of collection to w as JSON lines, one
+// PointResponse per line, and returns how many points were written. Each page
+// of IDs is fetched with a single GetPointsBatch call that bypasses the
+// PointCache, so memory use does not grow with the collection size. Points
+// deleted while the export runs are skipped. A canceled context stops the
+// export between points.
+func (c *Client) ExportCollection(ctx context.Context, collection string, w io.Writer) (exported int, err error) {
+	ctx, finish := c.withOperationTimeout(ctx)
+	defer func() { err = finish(err) }()
+	return c.exportCollection(ctx, collection, w)
+}
+
+func (c *Client) exportCollection(ctx context.Context, collection string, w io.Writer) (int, error) {
+	encoder := json.NewEncoder(w)
+	exported := 0
+	ids := make([]uint64, 0, exportPageSize)
+	flush := func() error {
+		points, err := c.GetPointsBatch(ctx, collection, ids, nil, WithNoCache())
+		if err != nil {
+			return err
+		}
+		ids = ids[:0]
+		for _, point := range points {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := encoder.Encode(point); err != nil {
+				return err
+			}
+			exported++
+		}
+		return nil
+	}
+
+	iterator := c.IteratePoints(ctx, collection, &ListPointsOptions{Limit: IntPtr(exportPageSize)})
+	defer iterator.Close()
+	for iterator.Next() {
+		ids = append(ids, iterator.Point().ID)
+		if len(ids) == exportPageSize {
+			if err := flush(); err != nil {
+				return exported, err
+			}
+		}
+	}
+	if err := iterator.Err(); err != nil {
+		return exported, err
+	}
+	if len(ids) > 0 {
+		if err := flush(); err != nil {
+			return exported, err
+		}
+	}
+	return exported, nil
+}
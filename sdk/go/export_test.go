@@ -0,0 +1,110 @@
+package aionbd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newExportServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/collections/demo/points":
+			writeJSON(t, writer, map[string]any{
+				"points":        []map[string]any{{"id": 1}, {"id": 2}, {"id": 3}},
+				"total":         3,
+				"next_offset":   nil,
+				"next_after_id": nil,
+			})
+		case "/collections/demo/points/get":
+			var body struct {
+				IDs []uint64 `json:"ids"`
+			}
+			if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+				t.Errorf("decode batch get: %v", err)
+			}
+			points := make([]map[string]any, 0, len(body.IDs))
+			for _, id := range body.IDs {
+				points = append(points, map[string]any{
+					"id":      id,
+					"values":  []float32{1, 0.5},
+					"payload": map[string]any{"label": fmt.Sprintf("p%d", id)},
+				})
+			}
+			writeJSON(t, writer, map[string]any{"points": points})
+		default:
+			t.Errorf("unexpected request %s %s", request.Method, request.URL.Path)
+			http.NotFound(writer, request)
+		}
+	}))
+}
+
+func TestExportCollectionWritesRoundTrippableLines(t *testing.T) {
+	t.Parallel()
+
+	server := newExportServer(t)
+	defer server.Close()
+
+	var output bytes.Buffer
+	client := NewClient(server.URL, &ClientOptions{PointCache: &PointCacheConfig{}})
+	exported, err := client.ExportCollection(context.Background(), "demo", &output)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if exported != 3 {
+		t.Fatalf("unexpected export count: %d", exported)
+	}
+
+	scanner := bufio.NewScanner(&output)
+	lines := 0
+	for scanner.Scan() {
+		var point PointResponse
+		if err := json.Unmarshal(scanner.Bytes(), &point); err != nil {
+			t.Fatalf("line %d does not decode: %v", lines+1, err)
+		}
+		lines++
+		if point.ID != uint64(lines) || len(point.Values) != 2 || point.Payload["label"] == nil {
+			t.Fatalf("unexpected point on line %d: %#v", lines, point)
+		}
+	}
+	if lines != 3 {
+		t.Fatalf("unexpected line count: %d", lines)
+	}
+	if cached := len(client.pointCache.entries); cached != 0 {
+		t.Fatalf("expected the export to bypass the point cache, got %d entries", cached)
+	}
+}
+
+func TestExportCollectionStopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	server := newExportServer(t)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	writer := cancelingWriter{cancel: cancel}
+	client := NewClient(server.URL, nil)
+	exported, err := client.ExportCollection(ctx, "demo", &writer)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+	if exported != 1 {
+		t.Fatalf("expected export to stop after the first point, got %d", exported)
+	}
+}
+
+type cancelingWriter struct {
+	cancel context.CancelFunc
+}
+
+func (writer *cancelingWriter) Write(payload []byte) (int, error) {
+	writer.cancel()
+	return len(payload), nil
+}
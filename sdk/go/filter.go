@@ -0,0 +1,180 @@
+package aionbd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+type FilterOp string
+
+const (
+	FilterOpEq     FilterOp = "eq"
+	FilterOpGt     FilterOp = "gt"
+	FilterOpGte    FilterOp = "gte"
+	FilterOpLt     FilterOp = "lt"
+	FilterOpLte    FilterOp = "lte"
+	FilterOpIn     FilterOp = "in"
+	FilterOpNotIn  FilterOp = "not_in"
+	FilterOpExists FilterOp = "exists"
+	// FilterOpBetween is an inclusive numeric range; build it with Between.
+	FilterOpBetween FilterOp = "between"
+)
+
+// Condition is a single filter clause. Eq marshals to the server's match
+// clause ({"field", "value"}) and Gt/Gte/Lt/Lte/Between to a range clause.
+// In, NotIn and Exists marshal to {"field", "in"|"not_in"|"exists"}; servers
+// that only understand match and range clauses reject them.
+type Condition struct {
+	Field string
+	Op    FilterOp
+	Value any
+}
+
+func (c Condition) Validate() error {
+	if strings.TrimSpace(c.Field) == "" {
+		return fmt.Errorf("filter field must not be empty")
+	}
+	switch c.Op {
+	case FilterOpEq:
+		if c.Value == nil {
+			return fmt.Errorf("filter %s on %q must have a value", c.Op, c.Field)
+		}
+	case FilterOpGt, FilterOpGte, FilterOpLt, FilterOpLte:
+		bound, ok := numericValue(c.Value)
+		if !ok {
+			return fmt.Errorf("filter %s on %q must have a numeric value, got %T", c.Op, c.Field, c.Value)
+		}
+		if math.IsNaN(bound) || math.IsInf(bound, 0) {
+			return fmt.Errorf("filter %s on %q must have a finite value, got %v", c.Op, c.Field, bound)
+		}
+	case FilterOpIn, FilterOpNotIn:
+		if !isSliceValue(c.Value) {
+			return fmt.Errorf("filter %s on %q must have a slice value, got %T", c.Op, c.Field, c.Value)
+		}
+	case FilterOpExists:
+	case FilterOpBetween:
+		bounds, ok := c.Value.([2]float64)
+		if !ok {
+			return fmt.Errorf("filter between on %q must have [2]float64 bounds, got %T", c.Field, c.Value)
+		}
+		return validateBounds(c.Field, bounds[0], bounds[1])
+	default:
+		return fmt.Errorf("unknown filter operator %q", c.Op)
+	}
+	return nil
+}
+
+func (c Condition) MarshalJSON() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	clause := map[string]any{"field": c.Field}
+	switch c.Op {
+	case FilterOpEq:
+		clause["value"] = c.Value
+	case FilterOpExists:
+		exists := true
+		if value, ok := c.Value.(bool); ok {
+			exists = value
+		}
+		clause["exists"] = exists
+	case FilterOpBetween:
+		bounds := c.Value.([2]float64)
+		clause["gte"] = bounds[0]
+		clause["lte"] = bounds[1]
+	default:
+		clause[string(c.Op)] = c.Value
+	}
+	return json.Marshal(clause)
+}
+
+// Between matches payload values in the inclusive range [lo, hi]. It emits a
+// single range clause with gte and lte bounds.
+func Between(field string, lo, hi float64) (Condition, error) {
+	condition := Condition{Field: field, Op: FilterOpBetween, Value: [2]float64{lo, hi}}
+	if err := condition.Validate(); err != nil {
+		return Condition{}, err
+	}
+	return condition, nil
+}
+
+func validateBounds(field string, lo, hi float64) error {
+	if math.IsNaN(lo) || math.IsNaN(hi) || math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+		return fmt.Errorf("filter between on %q must have finite bounds, got [%v, %v]", field, lo, hi)
+	}
+	if lo > hi {
+		return fmt.Errorf("filter between on %q must have lo <= hi, got %v > %v", field, lo, hi)
+	}
+	return nil
+}
+
+// numericValue converts the Go number types and json.Number to the float64
+// bound the server's range clause expects.
+func numericValue(value any) (float64, bool) {
+	if number, ok := value.(json.Number); ok {
+		parsed, err := number.Float64()
+		return parsed, err == nil
+	}
+	if value == nil {
+		return 0, false
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflected.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(reflected.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return reflected.Float(), true
+	}
+	return 0, false
+}
+
+func isSliceValue(value any) bool {
+	if value == nil {
+		return false
+	}
+	kind := reflect.TypeOf(value).Kind()
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// Filter builds the server filter DSL. Use Map to obtain the value for
+// SearchOptions.Filter.
+type Filter struct {
+	Must               []Condition
+	Should             []Condition
+	MustNot            []Condition
+	MinimumShouldMatch *int
+}
+
+func (f Filter) Map() (map[string]any, error) {
+	filter := map[string]any{}
+	for _, group := range []struct {
+		key        string
+		conditions []Condition
+	}{
+		{"must", f.Must},
+		{"should", f.Should},
+		{"must_not", f.MustNot},
+	} {
+		if len(group.conditions) == 0 {
+			continue
+		}
+		for _, condition := range group.conditions {
+			if err := condition.Validate(); err != nil {
+				return nil, err
+			}
+		}
+		filter[group.key] = group.conditions
+	}
+	if f.MinimumShouldMatch != nil {
+		if *f.MinimumShouldMatch < 0 || *f.MinimumShouldMatch > len(f.Should) {
+			return nil, fmt.Errorf("minimum_should_match must be between 0 and the number of should conditions")
+		}
+		filter["minimum_should_match"] = *f.MinimumShouldMatch
+	}
+	return filter, nil
+}
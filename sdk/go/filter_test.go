@@ -0,0 +1,107 @@
+package aionbd
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestConditionMarshalsEachOperator(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		condition Condition
+		expected  string
+	}{
+		{Condition{Field: "tier", Op: FilterOpEq, Value: "gold"}, `{"field":"tier","value":"gold"}`},
+		{Condition{Field: "score", Op: FilterOpGt, Value: 1.5}, `{"field":"score","gt":1.5}`},
+		{Condition{Field: "score", Op: FilterOpGte, Value: 2}, `{"field":"score","gte":2}`},
+		{Condition{Field: "score", Op: FilterOpLt, Value: 3}, `{"field":"score","lt":3}`},
+		{Condition{Field: "score", Op: FilterOpLte, Value: 4}, `{"field":"score","lte":4}`},
+		{Condition{Field: "tier", Op: FilterOpIn, Value: []string{"gold", "silver"}}, `{"field":"tier","in":["gold","silver"]}`},
+		{Condition{Field: "tier", Op: FilterOpNotIn, Value: []int{1, 2}}, `{"field":"tier","not_in":[1,2]}`},
+		{Condition{Field: "tier", Op: FilterOpExists}, `{"exists":true,"field":"tier"}`},
+	}
+	for _, tc := range cases {
+		encoded, err := json.Marshal(tc.condition)
+		if err != nil {
+			t.Fatalf("marshal %s failed: %v", tc.condition.Op, err)
+		}
+		if string(encoded) != tc.expected {
+			t.Fatalf("unexpected %s JSON: %s", tc.condition.Op, encoded)
+		}
+	}
+}
+
+func TestConditionRejectsNonSliceMembership(t *testing.T) {
+	t.Parallel()
+
+	for _, op := range []FilterOp{FilterOpIn, FilterOpNotIn} {
+		_, err := json.Marshal(Condition{Field: "tier", Op: op, Value: "gold"})
+		if err == nil || !strings.Contains(err.Error(), "must have a slice value") {
+			t.Fatalf("expected slice validation error for %s, got: %v", op, err)
+		}
+	}
+}
+
+func TestConditionRejectsNonNumericRangeBounds(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []any{"10", nil, true, math.NaN(), math.Inf(1)} {
+		if err := (Condition{Field: "price", Op: FilterOpGte, Value: value}).Validate(); err == nil {
+			t.Fatalf("expected %v (%T) to be rejected as a range bound", value, value)
+		}
+	}
+	for _, value := range []any{10, uint8(3), float32(2.5), json.Number("7")} {
+		if err := (Condition{Field: "price", Op: FilterOpLt, Value: value}).Validate(); err != nil {
+			t.Fatalf("expected %v (%T) to be accepted: %v", value, value, err)
+		}
+	}
+}
+
+func TestFilterMapBuildsServerDSL(t *testing.T) {
+	t.Parallel()
+
+	filter, err := Filter{
+		Must:               []Condition{{Field: "tier", Op: FilterOpEq, Value: "gold"}},
+		Should:             []Condition{{Field: "score", Op: FilterOpGte, Value: 0.5}},
+		MinimumShouldMatch: IntPtr(1),
+	}.Map()
+	if err != nil {
+		t.Fatalf("build filter failed: %v", err)
+	}
+	encoded, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatalf("marshal filter failed: %v", err)
+	}
+	expected := `{"minimum_should_match":1,"must":[{"field":"tier","value":"gold"}],"should":[{"field":"score","gte":0.5}]}`
+	if string(encoded) != expected {
+		t.Fatalf("unexpected filter JSON: %s", encoded)
+	}
+}
+
+func TestBetweenEmitsInclusiveRangeClause(t *testing.T) {
+	t.Parallel()
+
+	condition, err := Between("price", 10, 25.5)
+	if err != nil {
+		t.Fatalf("between failed: %v", err)
+	}
+	encoded, err := json.Marshal(condition)
+	if err != nil {
+		t.Fatalf("marshal between failed: %v", err)
+	}
+	if string(encoded) != `{"field":"price","gte":10,"lte":25.5}` {
+		t.Fatalf("unexpected between JSON: %s", encoded)
+	}
+
+	if _, err := Between("price", 30, 10); err == nil || !strings.Contains(err.Error(), "lo <= hi") {
+		t.Fatalf("expected bounds validation error, got: %v", err)
+	}
+	for _, bounds := range [][2]float64{{math.Inf(-1), 0}, {0, math.Inf(1)}, {math.NaN(), 1}} {
+		if _, err := Between("price", bounds[0], bounds[1]); err == nil || !strings.Contains(err.Error(), "finite bounds") {
+			t.Fatalf("expected %v to be rejected as non-finite, got: %v", bounds, err)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// float16Point is a point response that may carry its vector as "values_f16":
+// hex-encoded little-endian IEEE 754 half-precision values.
+type float16Point struct {
+	PointResponse
+	ValuesF16 string `json:"values_f16"`
+}
+
+// GetPointWithOptions is GetPoint with options. With Float16 it asks for
+// ?values=f16 and fills PointResponse.ValuesF16 instead of Values, halving
+// the memory a vector takes; Float16ToFloat32 expands it when needed. Servers
+// that do not send values_f16 answer with plain Values, which are kept as is.
+// Float16 reads bypass the point cache.
+func (c *Client) GetPointWithOptions(ctx context.Context, collection string, pointID uint64, options *GetPointOptions, opts ...CallOption) (PointResponse, error) {
+	if options == nil || !options.Float16 {
+		return c.GetPoint(ctx, collection, pointID, opts...)
+	}
+	path := fmt.Sprintf(pointPathFormat, collectionPathSegment(collection), pointID) + "?values=f16"
+	var response float16Point
+	if err := c.requestJSON(ctx, http.MethodGet, path, nil, &response, opts...); err != nil {
+		return PointResponse{}, err
+	}
+	point := response.PointResponse
+	if response.ValuesF16 != "" {
+		values, err := decodeFloat16Hex(response.ValuesF16)
+		if err != nil {
+			return PointResponse{}, &Error{Method: http.MethodGet, Path: path, Err: fmt.Errorf("invalid values_f16: %w", err)}
+		}
+		point.Values = nil
+		point.ValuesF16 = values
+	}
+	return point, nil
+}
+
+func decodeFloat16Hex(encoded string) ([]uint16, error) {
+	packed, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(packed)%2 != 0 {
+		return nil, fmt.Errorf("odd byte length %d", len(packed))
+	}
+	values := make([]uint16, len(packed)/2)
+	for index := range values {
+		values[index] = binary.LittleEndian.Uint16(packed[2*index:])
+	}
+	return values, nil
+}
+
+// Float16ToFloat32 expands IEEE 754 half-precision values to float32; every
+// half-precision value, including subnormals, infinities, and NaN, is exactly
+// representable.
+func Float16ToFloat32(v []uint16) []float32 {
+	values := make([]float32, len(v))
+	for index, half := range v {
+		values[index] = float16ToFloat32(half)
+	}
+	return values
+}
+
+// Float32ToFloat16 rounds float32 values to the nearest half-precision value
+// (ties to even). Magnitudes above 65504 become infinities and those below
+// about 6e-8 become zero.
+func Float32ToFloat16(v []float32) []uint16 {
+	values := make([]uint16, len(v))
+	for index, value := range v {
+		values[index] = float32ToFloat16(value)
+	}
+	return values
+}
+
+func float16ToFloat32(half uint16) float32 {
+	sign := uint32(half>>15) << 31
+	exponent := uint32(half>>10) & 0x1f
+	mantissa := uint32(half) & 0x3ff
+	switch {
+	case exponent == 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mantissa<<13)
+	case exponent == 0:
+		// Zero or subnormal: mantissa * 2^-24.
+		value := float32(mantissa) / (1 << 24)
+		if sign != 0 {
+			value = -value
+		}
+		return value
+	default:
+		return math.Float32frombits(sign | (exponent+127-15)<<23 | mantissa<<13)
+	}
+}
+
+func float32ToFloat16(value float32) uint16 {
+	bits := math.Float32bits(value)
+	sign := uint16(bits>>16) & 0x8000
+	exponent := int32(bits>>23) & 0xff
+	mantissa := bits & 0x7fffff
+	if exponent == 0xff {
+		if mantissa != 0 {
+			return sign | 0x7e00
+		}
+		return sign | 0x7c00
+	}
+
+	halfExponent := exponent - 127 + 15
+	if halfExponent >= 0x1f {
+		return sign | 0x7c00
+	}
+	if halfExponent <= 0 {
+		if halfExponent < -10 {
+			return sign
+		}
+		// Subnormal result: shift the implicit leading one into the mantissa.
+		mantissa |= 0x800000
+		shift := uint32(14 - halfExponent)
+		return sign | uint16(roundHalfEven(mantissa, shift))
+	}
+	// A carry out of the mantissa correctly bumps the exponent, up to infinity.
+	return sign | uint16(uint32(halfExponent)<<10+roundHalfEven(mantissa, 13))
+}
+
+func roundHalfEven(value uint32, shift uint32) uint32 {
+	result := value >> shift
+	remainder := value & (1<<shift - 1)
+	halfway := uint32(1) << (shift - 1)
+	if remainder > halfway || remainder == halfway && result&1 == 1 {
+		result++
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFloat16RoundTripWithinTolerance(t *testing.T) {
+	t.Parallel()
+
+	for half := range 1 << 16 {
+		value := float16ToFloat32(uint16(half))
+		if math.IsNaN(float64(value)) {
+			continue
+		}
+		if got := float32ToFloat16(value); got != uint16(half) {
+			t.Fatalf("half %#04x expanded to %v but packed back to %#04x", half, value, got)
+		}
+	}
+
+	random := rand.New(rand.NewSource(5))
+	vector := make([]float32, 256)
+	for index := range vector {
+		vector[index] = (random.Float32()*2 - 1) * 100
+	}
+	restored := Float16ToFloat32(Float32ToFloat16(vector))
+	for index, value := range vector {
+		if diff := math.Abs(float64(restored[index] - value)); diff > math.Abs(float64(value))/2048 {
+			t.Fatalf("value %d: %v round-tripped to %v", index, value, restored[index])
+		}
+	}
+
+	special := Float16ToFloat32(Float32ToFloat16([]float32{1e6, -1e6, 1e-9, float32(math.NaN())}))
+	if !math.IsInf(float64(special[0]), 1) || !math.IsInf(float64(special[1]), -1) || special[2] != 0 || !math.IsNaN(float64(special[3])) {
+		t.Fatalf("unexpected special values: %v", special)
+	}
+}
+
+func TestGetPointWithOptionsDecodesFloat16Values(t *testing.T) {
+	t.Parallel()
+
+	vector := []float32{0.5, -1.25, 3}
+	packed := make([]byte, 0, 2*len(vector))
+	for _, half := range Float32ToFloat16(vector) {
+		packed = binary.LittleEndian.AppendUint16(packed, half)
+	}
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		query = request.URL.RawQuery
+		writeJSON(t, writer, map[string]any{"id": 3, "values_f16": hex.EncodeToString(packed), "payload": map[string]any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	point, err := client.GetPointWithOptions(context.Background(), "demo", 3, &GetPointOptions{Float16: true})
+	if err != nil {
+		t.Fatalf("GetPointWithOptions returned error: %v", err)
+	}
+	if query != "values=f16" {
+		t.Fatalf("unexpected query %q", query)
+	}
+	if point.Values != nil || len(point.ValuesF16) != 3 {
+		t.Fatalf("expected compact float16 values only, got %+v", point)
+	}
+	for index, value := range Float16ToFloat32(point.ValuesF16) {
+		if value != vector[index] {
+			t.Fatalf("value %d: expected %v, got %v", index, vector[index], value)
+		}
+	}
+}
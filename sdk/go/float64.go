@@ -0,0 +1,43 @@
+package aionbd
+
+import (
+	"context"
+	"math"
+)
+
+// float64PrecisionWarnThreshold is the relative error above which converting a
+// float64 component to float32 is logged. Ordinary rounding stays far below
+// it; overflow, underflow to zero, and subnormals exceed it.
+const float64PrecisionWarnThreshold = 1e-6
+
+// SearchCollectionTopK64 is SearchCollectionTopK for float64 queries.
+func (c *Client) SearchCollectionTopK64(ctx context.Context, collection string, query []float64, options *SearchTopKOptions, opts ...CallOption) (SearchTopKResponse, error) {
+	return c.SearchCollectionTopK(ctx, collection, c.toFloat32(query, "query"), options, opts...)
+}
+
+// UpsertPoint64 is UpsertPoint for float64 values.
+func (c *Client) UpsertPoint64(ctx context.Context, collection string, pointID uint64, values []float64, payload PointPayload, opts ...CallOption) (UpsertPointResponse, error) {
+	return c.UpsertPoint(ctx, collection, pointID, c.toFloat32(values, "values"), payload, opts...)
+}
+
+// toFloat32 converts values, logging one warning for the component with the
+// largest relative error when it exceeds float64PrecisionWarnThreshold.
+func (c *Client) toFloat32(values []float64, name string) []float32 {
+	converted := make([]float32, len(values))
+	worstIndex, worstError := -1, 0.0
+	for index, value := range values {
+		converted[index] = float32(value)
+		if value == 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		relative := math.Abs(float64(converted[index])-value) / math.Abs(value)
+		if relative > worstError {
+			worstIndex, worstError = index, relative
+		}
+	}
+	if worstError > float64PrecisionWarnThreshold {
+		c.logger.Warn("aionbd: float64 to float32 conversion lost precision",
+			"vector", name, "index", worstIndex, "value", values[worstIndex], "relative_error", worstError)
+	}
+	return converted
+}
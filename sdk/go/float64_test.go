@@ -0,0 +1,53 @@
+package aionbd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSearchCollectionTopK64ConvertsQuery(t *testing.T) {
+	t.Parallel()
+
+	var query []float32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body struct {
+			Query []float32 `json:"query"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		query = body.Query
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": []map[string]any{{"id": 4, "value": 0.5}}})
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(server.URL, &ClientOptions{Logger: slog.New(slog.NewTextHandler(&logs, nil))})
+	response, err := client.SearchCollectionTopK64(context.Background(), "demo", []float64{0.25, -1.5}, nil)
+	if err != nil {
+		t.Fatalf("SearchCollectionTopK64 returned error: %v", err)
+	}
+	if len(query) != 2 || query[0] != 0.25 || query[1] != -1.5 {
+		t.Fatalf("unexpected converted query: %v", query)
+	}
+	if len(response.Hits) != 1 || response.Hits[0].ID != 4 {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+	if logs.Len() != 0 {
+		t.Fatalf("expected no precision warning, got %q", logs.String())
+	}
+
+	// 1e-50 underflows to zero in float32.
+	if _, err := client.UpsertPoint64(context.Background(), "demo", 1, []float64{1e-50}, nil); err != nil {
+		t.Fatalf("UpsertPoint64 returned error: %v", err)
+	}
+	if !strings.Contains(logs.String(), "lost precision") {
+		t.Fatalf("expected a precision warning, got %q", logs.String())
+	}
+}
@@ -0,0 +1,97 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const defaultGetCollectionsConcurrency = 8
+
+// CollectionsError lists the collections GetCollections could not fetch,
+// keyed by name.
+type CollectionsError struct {
+	Failed map[string]error
+}
+
+func (e *CollectionsError) Error() string {
+	names := sortedErrorKeys(e.Failed)
+	messages := make([]string, len(names))
+	for index, name := range names {
+		messages[index] = fmt.Sprintf("%s: %v", name, e.Failed[name])
+	}
+	return fmt.Sprintf("could not get %d collection(s): %s", len(names), strings.Join(messages, "; "))
+}
+
+func (e *CollectionsError) Unwrap() []error {
+	names := sortedErrorKeys(e.Failed)
+	errs := make([]error, len(names))
+	for index, name := range names {
+		errs[index] = e.Failed[name]
+	}
+	return errs
+}
+
+// Missing returns the names that do not exist (404), sorted.
+func (e *CollectionsError) Missing() []string {
+	var missing []string
+	for _, name := range sortedErrorKeys(e.Failed) {
+		if isStatus(e.Failed[name], http.StatusNotFound) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// GetCollections fetches the metadata of several collections concurrently,
+// serving names from the metadata cache when possible. Collections that could
+// not be fetched, e.g. missing ones, are left out of the map and reported in a
+// *CollectionsError; the others are still returned.
+func (c *Client) GetCollections(ctx context.Context, names []string, opts ...CallOption) (map[string]CollectionResponse, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	unique := make([]string, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if _, duplicate := seen[name]; !duplicate {
+			seen[name] = struct{}{}
+			unique = append(unique, name)
+		}
+	}
+
+	var mu sync.Mutex
+	results := make(map[string]CollectionResponse, len(unique))
+	failed := make(map[string]error)
+	pending := make(chan string)
+	var workers sync.WaitGroup
+	for range min(defaultGetCollectionsConcurrency, len(unique)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for name := range pending {
+				collection, err := c.collectionMetadata(ctx, name, opts...)
+				mu.Lock()
+				if err != nil {
+					failed[name] = err
+				} else {
+					results[name] = collection
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, name := range unique {
+		pending <- name
+	}
+	close(pending)
+	workers.Wait()
+
+	if len(failed) > 0 {
+		return results, &CollectionsError{Failed: failed}
+	}
+	return results, nil
+}
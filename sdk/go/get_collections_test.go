@@ -0,0 +1,66 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetCollectionsFetchesConcurrentlyAndReportsMissing(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	var arrived sync.WaitGroup
+	arrived.Add(3)
+	allArrived := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(allArrived)
+	}()
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		name := strings.TrimPrefix(request.URL.Path, "/collections/")
+		if requests.Load() <= 3 {
+			arrived.Done()
+			// Only answer once all three requests are in flight at once.
+			select {
+			case <-allArrived:
+			case <-time.After(2 * time.Second):
+				t.Errorf("request for %s was not fetched concurrently", name)
+			}
+		}
+		if name == "gone" {
+			http.Error(writer, "collection not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(t, writer, map[string]any{"name": name, "dimension": 4, "point_count": len(name)})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	collections, err := client.GetCollections(context.Background(), []string{"alpha", "gone", "beta", "alpha"})
+	var collectionsErr *CollectionsError
+	if !errors.As(err, &collectionsErr) {
+		t.Fatalf("expected *CollectionsError, got %v", err)
+	}
+	if missing := collectionsErr.Missing(); len(missing) != 1 || missing[0] != "gone" {
+		t.Fatalf("unexpected missing collections: %v", missing)
+	}
+	if len(collections) != 2 || collections["alpha"].PointCount != 5 || collections["beta"].Dimension != 4 {
+		t.Fatalf("unexpected collections: %+v", collections)
+	}
+
+	// Found collections are served from the metadata cache afterwards.
+	if _, err := client.GetCollections(context.Background(), []string{"alpha", "beta"}); err != nil {
+		t.Fatalf("cached GetCollections failed: %v", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Fatalf("expected 3 requests, got %d", got)
+	}
+}
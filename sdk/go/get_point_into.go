@@ -0,0 +1,61 @@
+package aionbd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// pointValues decodes only the values of a point response; the payload is
+// skipped without being materialized.
+type pointValues struct {
+	Values []float32 `json:"values"`
+}
+
+// GetPointInto fetches a point's vector into buf, growing it only when its
+// capacity is too small, and returns the filled slice. When the collection's
+// dimension is already in the metadata cache, a vector of a different length
+// is reported as an error.
+func (c *Client) GetPointInto(ctx context.Context, collection string, pointID uint64, buf []float32, opts ...CallOption) ([]float32, error) {
+	name := strings.TrimSpace(collection)
+	call := newCallConfig(opts)
+	// The scope hash is only worth computing when a cache could use it.
+	pointCached := c.pointCache != nil && !call.noCache && !call.capturesResponse()
+	var scope string
+	scoped := false
+	if pointCached || !c.metadata.empty() {
+		scope, scoped = c.hashedRequestScope(ctx, call)
+	}
+
+	decoded := pointValues{Values: buf[:0]}
+	point, found := PointResponse{}, false
+	if pointCached && scoped {
+		point, found = c.pointCache.get(scope, name, pointID)
+	}
+	if found {
+		decoded.Values = append(decoded.Values, point.Values...)
+	} else {
+		path := fmt.Sprintf(pointPathFormat, collectionPathSegment(name), pointID)
+		payload, err := c.doRequest(ctx, http.MethodGet, path, nil, false, call)
+		if err != nil {
+			return buf[:0], err
+		}
+		call.capture(payload)
+		if len(bytes.TrimSpace(payload)) == 0 {
+			return buf[:0], nil
+		}
+		if err := json.Unmarshal(payload, &decoded); err != nil {
+			return buf[:0], &Error{Method: http.MethodGet, Path: path, Body: string(payload), Err: fmt.Errorf("invalid JSON response: %w", err)}
+		}
+	}
+	if !scoped {
+		return decoded.Values, nil
+	}
+	if metadata, known := c.metadata.get(scope, name); known && metadata.Dimension > 0 && len(decoded.Values) != metadata.Dimension {
+		return decoded.Values, fmt.Errorf("point %d has %d values, collection %q has dimension %d", pointID, len(decoded.Values), name, metadata.Dimension)
+	}
+	return decoded.Values, nil
+}
@@ -0,0 +1,91 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetPointIntoReusesBufferAndChecksDimension(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if strings.HasSuffix(request.URL.Path, "/points/9") {
+			writeJSON(t, writer, map[string]any{"id": 9, "values": []float32{1, 2, 3}, "payload": map[string]any{"tag": "x"}})
+			return
+		}
+		writeJSON(t, writer, map[string]any{"id": 7, "values": []float32{0.5, -0.5}, "payload": map[string]any{"tag": "x"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	buf := make([]float32, 0, 4)
+	values, err := client.GetPointInto(context.Background(), "demo", 7, buf)
+	if err != nil {
+		t.Fatalf("GetPointInto returned error: %v", err)
+	}
+	if len(values) != 2 || values[0] != 0.5 || values[1] != -0.5 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+	if &values[:cap(values)][0] != &buf[:cap(buf)][0] {
+		t.Fatal("expected the caller buffer to be reused")
+	}
+
+	grown, err := client.GetPointInto(context.Background(), "demo", 7, nil)
+	if err != nil || len(grown) != 2 {
+		t.Fatalf("expected a grown buffer, got %v, %v", grown, err)
+	}
+
+	scope, _ := client.metadataScope(context.Background(), nil)
+	client.metadata.put(scope, "demo", CollectionResponse{Name: "demo", Dimension: 2})
+	if _, err := client.GetPointInto(context.Background(), "demo", 9, buf); err == nil || !strings.Contains(err.Error(), "dimension 2") {
+		t.Fatalf("expected a dimension mismatch error, got %v", err)
+	}
+}
+
+func BenchmarkGetPointInto(b *testing.B) {
+	values := make([]float32, 768)
+	for index := range values {
+		values[index] = float32(index) / 768
+	}
+	body, err := json.Marshal(map[string]any{"id": 1, "values": values, "payload": map[string]any{"tag": "bench"}})
+	if err != nil {
+		b.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write(body)
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, nil)
+	ctx := context.Background()
+
+	b.Run("GetPoint", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := client.GetPoint(ctx, "demo", 1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("GetPointInto", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]float32, 0, len(values))
+		for range b.N {
+			var err error
+			if buf, err = client.GetPointInto(ctx, "demo", 1, buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	buf := make([]float32, 0, len(values))
+	pointAllocs := testing.AllocsPerRun(20, func() { client.GetPoint(ctx, "demo", 1) })
+	intoAllocs := testing.AllocsPerRun(20, func() { buf, _ = client.GetPointInto(ctx, "demo", 1, buf) })
+	if intoAllocs >= pointAllocs {
+		b.Fatalf("GetPointInto made %.0f allocs/op, GetPoint %.0f", intoAllocs, pointAllocs)
+	}
+}
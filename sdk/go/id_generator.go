@@ -0,0 +1,40 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+)
+
+var ErrNoIDGenerator = errors.New("no IDGenerator configured")
+
+// FNVPayloadIDGenerator derives an ID from the FNV-1a hash of the payload's
+// canonical JSON, so equal payloads always map to the same point. Points
+// without a payload (or with one that cannot be encoded) are hashed by their
+// values instead.
+func FNVPayloadIDGenerator(values []float32, payload PointPayload) uint64 {
+	hash := fnv.New64a()
+	if len(payload) > 0 {
+		if encoded, err := canonicalJSON(payload); err == nil {
+			hash.Write(encoded)
+			return hash.Sum64()
+		}
+	}
+	var buffer [4]byte
+	for _, value := range values {
+		binary.LittleEndian.PutUint32(buffer[:], math.Float32bits(value))
+		hash.Write(buffer[:])
+	}
+	return hash.Sum64()
+}
+
+// UpsertPointAuto upserts a point under the ID chosen by
+// ClientOptions.IDGenerator and returns the response carrying that ID.
+func (c *Client) UpsertPointAuto(ctx context.Context, collection string, values []float32, payload PointPayload, opts ...CallOption) (UpsertPointResponse, error) {
+	if c.idGenerator == nil {
+		return UpsertPointResponse{}, ErrNoIDGenerator
+	}
+	return c.UpsertPoint(ctx, collection, c.idGenerator(values, payload), values, payload, opts...)
+}
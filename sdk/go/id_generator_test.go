@@ -0,0 +1,53 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFNVPayloadIDGeneratorIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	first := FNVPayloadIDGenerator([]float32{1, 2}, PointPayload{"doc": "a", "page": 3})
+	second := FNVPayloadIDGenerator([]float32{9, 9}, PointPayload{"page": 3, "doc": "a"})
+	if first != second {
+		t.Fatalf("expected equal payloads to share an id, got %d and %d", first, second)
+	}
+	if other := FNVPayloadIDGenerator([]float32{1, 2}, PointPayload{"doc": "b", "page": 3}); other == first {
+		t.Fatal("expected different payloads to get different ids")
+	}
+	if FNVPayloadIDGenerator([]float32{1, 2}, nil) != FNVPayloadIDGenerator([]float32{1, 2}, nil) {
+		t.Fatal("expected values-based ids to be deterministic")
+	}
+}
+
+func TestUpsertPointAutoUsesGenerator(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		paths = append(paths, request.URL.Path)
+		id := request.URL.Path[strings.LastIndex(request.URL.Path, "/")+1:]
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(`{"id":` + id + `,"created":true}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewClient(server.URL, nil).UpsertPointAuto(context.Background(), "demo", []float32{1}, nil); !errors.Is(err, ErrNoIDGenerator) {
+		t.Fatalf("expected ErrNoIDGenerator, got %v", err)
+	}
+
+	client := NewClient(server.URL, &ClientOptions{IDGenerator: FNVPayloadIDGenerator})
+	payload := PointPayload{"doc": "a"}
+	response, err := client.UpsertPointAuto(context.Background(), "demo", []float32{1}, payload)
+	if err != nil {
+		t.Fatalf("UpsertPointAuto returned error: %v", err)
+	}
+	if want := FNVPayloadIDGenerator([]float32{1}, payload); response.ID != want {
+		t.Fatalf("expected id %d, got %d (paths %v)", want, response.ID, paths)
+	}
+}
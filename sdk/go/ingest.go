@@ -0,0 +1,206 @@
+package aionbd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+const maxImportLineBytes = 64 << 20
+
+type IngestConfig struct {
+	StopOnError bool
+	// RetryFailedItems makes UpsertPointsChunked bisect a chunk the server
+	// rejected until the offending items are isolated, reporting them in
+	// ChunkedUpsertResponse.Failed instead of failing the call.
+	RetryFailedItems bool
+	// Adaptive uploads ImportCollection batches concurrently, with the
+	// worker count driven by server metrics. Nil keeps uploads sequential.
+	Adaptive *AdaptiveConcurrency
+}
+
+type IngestStats struct {
+	Lines   int
+	Created int
+	Updated int
+	Failed  int
+	Batches int
+}
+
+type ImportLineError struct {
+	Line int
+	Err  error
+}
+
+func (e *ImportLineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ImportLineError) Unwrap() error {
+	return e.Err
+}
+
+// ImportCollection reads JSON lines in the ExportCollection format from r and
+// upserts them in batches of batchSize. Blank lines are skipped; parse errors
+// and lines without an id or values report their line number. With ClientOptions.Ingest.StopOnError the
+// import aborts on the first failure; otherwise it keeps going and returns
+// every failure joined into one error alongside the stats. A canceled context
+// or an elapsed OperationTimeout always aborts. With Ingest.Adaptive, batches
+// are uploaded concurrently; see AdaptiveConcurrency.
+func (c *Client) ImportCollection(ctx context.Context, collection string, r io.Reader, batchSize int) (stats IngestStats, err error) {
+	if batchSize <= 0 {
+		return IngestStats{}, fmt.Errorf("batchSize must be a positive integer")
+	}
+	ctx, finish := c.withOperationTimeout(ctx)
+	defer func() { err = finish(err) }()
+	return c.importCollection(ctx, collection, r, batchSize)
+}
+
+// importLine tells a missing or null id or values apart from zero values, so
+// such a line is reported instead of overwriting point 0 with no vector.
+type importLine struct {
+	ID      *uint64      `json:"id"`
+	Values  []float32    `json:"values"`
+	Payload PointPayload `json:"payload"`
+}
+
+func parseImportLine(line []byte) (UpsertPointsBatchItem, error) {
+	var parsed importLine
+	if err := json.Unmarshal(line, &parsed); err != nil {
+		return UpsertPointsBatchItem{}, err
+	}
+	if parsed.ID == nil {
+		return UpsertPointsBatchItem{}, fmt.Errorf("missing id")
+	}
+	if parsed.Values == nil {
+		return UpsertPointsBatchItem{}, fmt.Errorf("missing values")
+	}
+	return UpsertPointsBatchItem{ID: *parsed.ID, Values: parsed.Values, Payload: parsed.Payload}, nil
+}
+
+func (c *Client) importCollection(ctx context.Context, collection string, r io.Reader, batchSize int) (IngestStats, error) {
+	ctx = withServerThrottle(ctx)
+	var mu sync.Mutex
+	var stats IngestStats
+	var failures []error
+	var aborted error
+	batch := make([]UpsertPointsBatchItem, 0, batchSize)
+	// fail records a failure and returns it when the import must stop; the
+	// caller holds mu.
+	fail := func(err error) error {
+		failures = append(failures, err)
+		if c.ingest.StopOnError {
+			aborted = err
+		}
+		return aborted
+	}
+	upload := func(batch []UpsertPointsBatchItem, index int) {
+		response, err := c.UpsertPointsBatch(ctx, collection, batch, withIdempotencyKey(nil, chunkIdempotencyKey(ctx, index))...)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			stats.Failed += len(batch)
+			if ctx.Err() != nil {
+				// Every later batch would fail the same way.
+				aborted = err
+				return
+			}
+			fail(err)
+			return
+		}
+		stats.Created += response.Created
+		stats.Updated += response.Updated
+	}
+
+	var limiter *adaptiveLimiter
+	var uploads sync.WaitGroup
+	if c.ingest.Adaptive != nil {
+		config := c.ingest.Adaptive.withDefaults()
+		limiter = newAdaptiveLimiter(config.Max)
+		controllerCtx, stopController := context.WithCancel(ctx)
+		defer stopController()
+		go c.runAdaptiveController(controllerCtx, config, limiter)
+	}
+	// flush uploads the pending batch, inline or on a worker, and reports an
+	// earlier failure that must stop the import.
+	flush := func() error {
+		mu.Lock()
+		abortErr := aborted
+		index := stats.Batches
+		if abortErr == nil && len(batch) > 0 {
+			stats.Batches++
+		}
+		mu.Unlock()
+		if abortErr != nil || len(batch) == 0 {
+			return abortErr
+		}
+		pending := batch
+		batch = make([]UpsertPointsBatchItem, 0, batchSize)
+		if limiter == nil {
+			upload(pending, index)
+		} else {
+			limiter.acquire()
+			uploads.Add(1)
+			go func() {
+				defer uploads.Done()
+				defer limiter.release()
+				upload(pending, index)
+			}()
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return aborted
+	}
+	finish := func(err error) (IngestStats, error) {
+		uploads.Wait()
+		mu.Lock()
+		defer mu.Unlock()
+		if err == nil {
+			err = aborted
+		}
+		if err != nil {
+			return stats, err
+		}
+		return stats, errors.Join(failures...)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+	for scanner.Scan() {
+		// Lines is only touched here, so it needs no lock.
+		stats.Lines++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		item, err := parseImportLine(line)
+		if err != nil {
+			mu.Lock()
+			stats.Failed++
+			abortErr := fail(&ImportLineError{Line: stats.Lines, Err: err})
+			mu.Unlock()
+			if abortErr != nil {
+				return finish(abortErr)
+			}
+			continue
+		}
+		batch = append(batch, item)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return finish(err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return finish(&ImportLineError{Line: stats.Lines + 1, Err: err})
+	}
+	if err := flush(); err != nil {
+		return finish(err)
+	}
+	return finish(nil)
+}
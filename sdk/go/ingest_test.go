@@ -0,0 +1,96 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const importFixture = `{"id":1,"values":[1,0],"payload":{"label":"a"}}
+
+{"id":2,"values":[0,1]}
+{"id":3,"values":[0.5,
+{"id":4,"values":[1,1]}
+`
+
+func newImportServer(t *testing.T, received *[]uint64) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body struct {
+			Points []UpsertPointsBatchItem `json:"points"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		for _, point := range body.Points {
+			*received = append(*received, point.ID)
+		}
+		writeJSON(t, writer, map[string]any{"created": len(body.Points), "updated": 0, "results": []any{}})
+	}))
+}
+
+func TestImportCollectionReportsMalformedLineAndContinues(t *testing.T) {
+	t.Parallel()
+
+	var received []uint64
+	server := newImportServer(t, &received)
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	stats, err := client.ImportCollection(context.Background(), "demo", strings.NewReader(importFixture), 2)
+
+	var lineErr *ImportLineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 4 {
+		t.Fatalf("expected a line 4 parse error, got %v", err)
+	}
+	if stats.Created != 3 || stats.Failed != 1 || stats.Batches != 2 || stats.Lines != 5 {
+		t.Fatalf("unexpected stats: %#v", stats)
+	}
+	if len(received) != 3 || received[2] != 4 {
+		t.Fatalf("unexpected upserted ids: %v", received)
+	}
+}
+
+func TestImportCollectionRejectsLinesWithoutIDOrValues(t *testing.T) {
+	t.Parallel()
+
+	var received []uint64
+	server := newImportServer(t, &received)
+	defer server.Close()
+
+	lines := `{"foo":1}` + "\n" + `{"id":2}` + "\n" + `{"id":3,"values":null}` + "\n" + `{"id":0,"values":[1]}` + "\n"
+	stats, err := NewClient(server.URL, nil).ImportCollection(context.Background(), "demo", strings.NewReader(lines), 10)
+	var lineErr *ImportLineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 1 || !strings.Contains(err.Error(), "missing id") {
+		t.Fatalf("expected a missing id error on line 1, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "line 2: missing values") || !strings.Contains(err.Error(), "line 3: missing values") {
+		t.Fatalf("expected lines 2 and 3 to be reported, got %v", err)
+	}
+	if stats.Failed != 3 || len(received) != 1 || received[0] != 0 {
+		t.Fatalf("expected only the explicit point 0 to be upserted, got stats %#v and ids %v", stats, received)
+	}
+}
+
+func TestImportCollectionStopsOnError(t *testing.T) {
+	t.Parallel()
+
+	var received []uint64
+	server := newImportServer(t, &received)
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{Ingest: IngestConfig{StopOnError: true}})
+	stats, err := client.ImportCollection(context.Background(), "demo", strings.NewReader(importFixture), 10)
+
+	var lineErr *ImportLineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 4 {
+		t.Fatalf("expected a line 4 parse error, got %v", err)
+	}
+	if len(received) != 0 || stats.Created != 0 {
+		t.Fatalf("expected no upserts before the malformed line was hit, got %v (%#v)", received, stats)
+	}
+}
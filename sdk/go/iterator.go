@@ -0,0 +1,120 @@
+package aionbd
+
+import "context"
+
+// PointIterator walks every point of a collection page by page. Pages are
+// chained with the server's opaque NextToken when present, falling back to the
+// NextAfterID cursor otherwise. Callers should defer it.Close() so an
+// abandoned iteration cancels any in-flight page request.
+type PointIterator struct {
+	client     *Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	closed     bool
+	collection string
+	options    ListPointsOptions
+	afterID    *uint64
+	pageToken  *string
+	started    bool
+	done       bool
+	page       []PointIDResponse
+	index      int
+	// closedMidPage records that Close dropped points of the page not yet
+	// returned, so State still checkpoints from the current point.
+	closedMidPage bool
+	current       PointIDResponse
+	err           error
+}
+
+func (c *Client) IteratePoints(ctx context.Context, collection string, options *ListPointsOptions) *PointIterator {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(WithSession(ctx))
+	iterator := &PointIterator{
+		client:     c,
+		ctx:        ctx,
+		cancel:     cancel,
+		collection: collection,
+	}
+	if options != nil {
+		iterator.options = *options
+		iterator.afterID = options.AfterID
+		iterator.pageToken = options.PageToken
+	}
+	return iterator
+}
+
+func (it *PointIterator) Next() bool {
+	if it.closed {
+		return false
+	}
+	for it.index >= len(it.page) {
+		if it.err != nil || (it.started && it.done) {
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+func (it *PointIterator) Point() PointIDResponse {
+	return it.current
+}
+
+func (it *PointIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration, cancels any in-flight page request, and drops
+// the buffered page. Next returns false afterwards; State still checkpoints
+// right after the last point Next returned. Close is idempotent and always
+// returns nil.
+func (it *PointIterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	it.cancel()
+	it.closedMidPage = it.index < len(it.page)
+	it.page = nil
+	it.index = 0
+	return nil
+}
+
+func (it *PointIterator) fetch() error {
+	options := it.options
+	options.AfterID = it.afterID
+	options.PageToken = it.pageToken
+	if it.started || it.pageToken != nil || it.afterID != nil {
+		options.Offset = 0
+	}
+	if options.PageToken != nil {
+		options.AfterID = nil
+	}
+
+	response, err := it.client.ListPoints(it.ctx, it.collection, &options)
+	if err != nil {
+		return err
+	}
+	it.started = true
+	it.page = response.Points
+	it.index = 0
+
+	switch {
+	case response.NextToken != nil && *response.NextToken != "":
+		it.pageToken = response.NextToken
+		it.afterID = nil
+	case response.NextAfterID != nil:
+		it.pageToken = nil
+		it.afterID = response.NextAfterID
+	default:
+		it.done = true
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const iteratorStateVersion = 1
+
+type iteratorState struct {
+	Version        int      `json:"v"`
+	Collection     string   `json:"collection"`
+	AfterID        *uint64  `json:"after_id,omitempty"`
+	PageToken      *string  `json:"page_token,omitempty"`
+	Offset         int      `json:"offset,omitempty"`
+	Limit          *int     `json:"limit,omitempty"`
+	IncludePayload bool     `json:"include_payload,omitempty"`
+	Fields         []string `json:"fields,omitempty"`
+	Done           bool     `json:"done,omitempty"`
+}
+
+// State returns a checkpoint of the iteration that ResumePointIterator turns
+// back into an iterator continuing right after the last point returned by
+// Next, even in another process. Inside a page the checkpoint is that point's
+// ID as after_id; at a page boundary it is the server's next cursor.
+func (it *PointIterator) State() []byte {
+	state := iteratorState{
+		Version:        iteratorStateVersion,
+		Collection:     strings.TrimSpace(it.collection),
+		Limit:          it.options.Limit,
+		IncludePayload: it.options.IncludePayload,
+		Fields:         it.options.Fields,
+	}
+	switch {
+	case !it.started:
+		state.AfterID = it.afterID
+		state.PageToken = it.pageToken
+		state.Offset = it.options.Offset
+	case it.index < len(it.page) || it.closedMidPage:
+		id := it.current.ID
+		state.AfterID = &id
+	default:
+		state.AfterID = it.afterID
+		state.PageToken = it.pageToken
+		state.Done = it.done
+	}
+	encoded, _ := json.Marshal(state)
+	return encoded
+}
+
+// ResumePointIterator continues an iteration from a State checkpoint. The
+// checkpoint must come from an iteration over the same collection.
+func (c *Client) ResumePointIterator(ctx context.Context, collection string, state []byte) (*PointIterator, error) {
+	var decoded iteratorState
+	if err := json.Unmarshal(state, &decoded); err != nil {
+		return nil, fmt.Errorf("invalid iterator state: %w", err)
+	}
+	if decoded.Version != iteratorStateVersion {
+		return nil, fmt.Errorf("unsupported iterator state version %d", decoded.Version)
+	}
+	if decoded.Collection != strings.TrimSpace(collection) {
+		return nil, fmt.Errorf("iterator state belongs to collection %q, not %q", decoded.Collection, collection)
+	}
+	iterator := c.IteratePoints(ctx, collection, &ListPointsOptions{
+		Offset:         decoded.Offset,
+		Limit:          decoded.Limit,
+		AfterID:        decoded.AfterID,
+		PageToken:      decoded.PageToken,
+		IncludePayload: decoded.IncludePayload,
+		Fields:         decoded.Fields,
+	})
+	if decoded.Done {
+		iterator.started = true
+		iterator.done = true
+	}
+	return iterator, nil
+}
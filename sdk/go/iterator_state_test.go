@@ -0,0 +1,90 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestPointIteratorResumesFromState(t *testing.T) {
+	t.Parallel()
+
+	const total = 10
+	server := httptest.NewServer(afterIDPagesHandler(t, total))
+	defer server.Close()
+
+	ctx := context.Background()
+	for _, checkpointAfter := range []int{0, 3, 4, total} {
+		first := NewClient(server.URL, nil).IteratePoints(ctx, "demo", &ListPointsOptions{Limit: IntPtr(3)})
+		var ids []uint64
+		for len(ids) < checkpointAfter && first.Next() {
+			ids = append(ids, first.Point().ID)
+		}
+		state := first.State()
+		first.Close()
+
+		resumed, err := NewClient(server.URL, nil).ResumePointIterator(ctx, "demo", state)
+		if err != nil {
+			t.Fatalf("checkpoint after %d: ResumePointIterator failed: %v", checkpointAfter, err)
+		}
+		for resumed.Next() {
+			ids = append(ids, resumed.Point().ID)
+		}
+		if err := resumed.Err(); err != nil {
+			t.Fatalf("checkpoint after %d: resumed iteration failed: %v", checkpointAfter, err)
+		}
+		want := []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		if !slices.Equal(ids, want) {
+			t.Fatalf("checkpoint after %d: expected %v without gaps or duplicates, got %v", checkpointAfter, want, ids)
+		}
+	}
+
+	state := NewClient(server.URL, nil).IteratePoints(ctx, "demo", nil).State()
+	if _, err := NewClient(server.URL, nil).ResumePointIterator(ctx, "other", state); err == nil {
+		t.Fatal("expected a state from another collection to be rejected")
+	}
+}
+
+func TestPointIteratorStateAfterCloseKeepsRestOfPage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(afterIDPagesHandler(t, 6))
+	defer server.Close()
+
+	ctx := context.Background()
+	first := NewClient(server.URL, nil).IteratePoints(ctx, "demo", &ListPointsOptions{Limit: IntPtr(3)})
+	first.Next()
+	first.Close()
+	resumed, err := NewClient(server.URL, nil).ResumePointIterator(ctx, "demo", first.State())
+	if err != nil {
+		t.Fatalf("ResumePointIterator failed: %v", err)
+	}
+	var ids []uint64
+	for resumed.Next() {
+		ids = append(ids, resumed.Point().ID)
+	}
+	if want := []uint64{2, 3, 4, 5, 6}; !slices.Equal(ids, want) {
+		t.Fatalf("expected %v after a checkpoint taken once closed, got %v", want, ids)
+	}
+}
+
+// afterIDPagesHandler serves points 1..total in pages chained by after_id.
+func afterIDPagesHandler(t *testing.T, total int) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		query := request.URL.Query()
+		afterID, _ := strconv.Atoi(query.Get("after_id"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		var points []map[string]any
+		for id := afterID + 1; id <= total && len(points) < limit; id++ {
+			points = append(points, map[string]any{"id": id})
+		}
+		response := map[string]any{"points": points, "total": total}
+		if last := afterID + len(points); last < total {
+			response["next_after_id"] = last
+		}
+		writeJSON(t, writer, response)
+	})
+}
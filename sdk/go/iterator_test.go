@@ -0,0 +1,96 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestPointIteratorPrefersOpaqueNextToken(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		queries = append(queries, request.URL.RawQuery)
+		switch request.URL.Query().Get("page_token") {
+		case "":
+			writeJSON(t, writer, map[string]any{
+				"points":        []map[string]any{{"id": 1}, {"id": 2}},
+				"total":         3,
+				"next_offset":   2,
+				"next_after_id": 2,
+				"next_token":    "opaque-page-2",
+			})
+		case "opaque-page-2":
+			writeJSON(t, writer, map[string]any{
+				"points":        []map[string]any{{"id": 3}},
+				"total":         3,
+				"next_offset":   nil,
+				"next_after_id": nil,
+			})
+		default:
+			t.Fatalf("unexpected query: %s", request.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	iterator := client.IteratePoints(context.Background(), "demo", &ListPointsOptions{Limit: IntPtr(2)})
+	var ids []uint64
+	for iterator.Next() {
+		ids = append(ids, iterator.Point().ID)
+	}
+	if err := iterator.Err(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+
+	if len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+	if len(queries) != 2 || queries[1] != "limit=2&page_token=opaque-page-2" {
+		t.Fatalf("expected the opaque token to drive the second page, got: %v", queries)
+	}
+}
+
+func TestPointIteratorCloseReleasesAbandonedIteration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writeJSON(t, writer, map[string]any{
+			"points":        []map[string]any{{"id": 1}, {"id": 2}},
+			"total":         100,
+			"next_after_id": 2,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	before := runtime.NumGoroutine()
+
+	iterator := client.IteratePoints(context.Background(), "demo", &ListPointsOptions{Limit: IntPtr(2)})
+	if !iterator.Next() {
+		t.Fatalf("expected a first point, got err: %v", iterator.Err())
+	}
+	if err := iterator.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	if iterator.Next() {
+		t.Fatal("expected Next to stop after Close")
+	}
+	if iterator.Err() != nil {
+		t.Fatalf("expected no error after Close, got: %v", iterator.Err())
+	}
+	if err := iterator.ctx.Err(); err == nil {
+		t.Fatal("expected Close to cancel the iterator context")
+	}
+
+	client.httpClient.CloseIdleConnections()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutines leaked: before=%d after=%d", before, after)
+	}
+}
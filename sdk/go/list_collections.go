@@ -0,0 +1,53 @@
+package aionbd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type CollectionSortField string
+
+const (
+	CollectionSortByName       CollectionSortField = "name"
+	CollectionSortByPointCount CollectionSortField = "point_count"
+)
+
+// ListCollectionsOptions narrows and orders a ListCollectionsFiltered result.
+// Empty SortBy keeps the server order.
+type ListCollectionsOptions struct {
+	NamePrefix string
+	MinPoints  int
+	SortBy     CollectionSortField
+}
+
+// ListCollectionsFiltered fetches every collection with ListCollections and
+// filters and sorts them locally; the server has no such parameters. Sorting
+// by point count is ascending, with ties ordered by name.
+func (c *Client) ListCollectionsFiltered(ctx context.Context, options ListCollectionsOptions, opts ...CallOption) (ListCollectionsResponse, error) {
+	switch options.SortBy {
+	case "", CollectionSortByName, CollectionSortByPointCount:
+	default:
+		return ListCollectionsResponse{}, fmt.Errorf("sort field must be one of name, point_count, got %q", options.SortBy)
+	}
+	response, err := c.ListCollections(ctx, opts...)
+	if err != nil {
+		return response, err
+	}
+	response.Collections = slices.DeleteFunc(response.Collections, func(collection CollectionResponse) bool {
+		return !strings.HasPrefix(collection.Name, options.NamePrefix) || collection.PointCount < options.MinPoints
+	})
+	switch options.SortBy {
+	case CollectionSortByName:
+		slices.SortFunc(response.Collections, func(a, b CollectionResponse) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	case CollectionSortByPointCount:
+		slices.SortFunc(response.Collections, func(a, b CollectionResponse) int {
+			return cmp.Or(cmp.Compare(a.PointCount, b.PointCount), strings.Compare(a.Name, b.Name))
+		})
+	}
+	return response, nil
+}
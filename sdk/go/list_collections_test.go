@@ -0,0 +1,51 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestListCollectionsFilteredFiltersAndSorts(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writeJSON(t, writer, map[string]any{"collections": []map[string]any{
+			{"name": "logs-b", "dimension": 4, "point_count": 300},
+			{"name": "users", "dimension": 4, "point_count": 900},
+			{"name": "logs-c", "dimension": 4, "point_count": 5},
+			{"name": "logs-a", "dimension": 4, "point_count": 300},
+			{"name": "logs-d", "dimension": 4, "point_count": 120},
+		}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	cases := []struct {
+		options ListCollectionsOptions
+		want    []string
+	}{
+		{ListCollectionsOptions{}, []string{"logs-b", "users", "logs-c", "logs-a", "logs-d"}},
+		{ListCollectionsOptions{NamePrefix: "logs-", SortBy: CollectionSortByName}, []string{"logs-a", "logs-b", "logs-c", "logs-d"}},
+		{ListCollectionsOptions{MinPoints: 100, SortBy: CollectionSortByPointCount}, []string{"logs-d", "logs-a", "logs-b", "users"}},
+	}
+	for _, tc := range cases {
+		response, err := client.ListCollectionsFiltered(context.Background(), tc.options)
+		if err != nil {
+			t.Fatalf("%+v: %v", tc.options, err)
+		}
+		var names []string
+		for _, collection := range response.Collections {
+			names = append(names, collection.Name)
+		}
+		if !slices.Equal(names, tc.want) {
+			t.Fatalf("%+v: expected %v, got %v", tc.options, tc.want, names)
+		}
+	}
+
+	if _, err := client.ListCollectionsFiltered(context.Background(), ListCollectionsOptions{SortBy: "size"}); err == nil {
+		t.Fatal("expected an unknown sort field to be rejected")
+	}
+}
@@ -0,0 +1,149 @@
+package aionbd
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultMetadataCacheTTL = 30 * time.Second
+
+// metadataCache remembers collection metadata for helpers that need it
+// repeatedly (dimension lookups, ...). Entries are keyed by the request scope
+// (credentials and headers, as in the point cache) as well as the name, since
+// the server scopes collections per tenant. Failed lookups are not cached.
+// Default metrics are kept apart, in the same scopes, and do not expire: the
+// server does not store them, so a client only knows the ones it created or
+// saw reported in this process.
+type metadataCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[metadataKey]metadataCacheEntry
+	metrics map[metadataKey]Metric
+}
+
+type metadataKey struct {
+	scope string
+	name  string
+}
+
+type metadataCacheEntry struct {
+	collection CollectionResponse
+	expiresAt  time.Time
+}
+
+func newMetadataCache(ttl time.Duration) *metadataCache {
+	if ttl <= 0 {
+		ttl = defaultMetadataCacheTTL
+	}
+	return &metadataCache{ttl: ttl, entries: make(map[metadataKey]metadataCacheEntry), metrics: make(map[metadataKey]Metric)}
+}
+
+func (cache *metadataCache) get(scope string, name string) (CollectionResponse, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	key := metadataKey{scope, name}
+	entry, found := cache.entries[key]
+	if !found {
+		return CollectionResponse{}, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(cache.entries, key)
+		return CollectionResponse{}, false
+	}
+	return entry.collection, true
+}
+
+// empty reports whether no collection metadata is cached in any scope, so
+// callers can skip computing a scope for a lookup that cannot hit.
+func (cache *metadataCache) empty() bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return len(cache.entries) == 0
+}
+
+func (cache *metadataCache) put(scope string, name string, collection CollectionResponse) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.entries[metadataKey{scope, name}] = metadataCacheEntry{collection: collection, expiresAt: time.Now().Add(cache.ttl)}
+	if collection.DefaultMetric != "" {
+		cache.metrics[metadataKey{scope, name}] = collection.DefaultMetric
+	}
+}
+
+func (cache *metadataCache) defaultMetric(scope string, name string) Metric {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.metrics[metadataKey{scope, name}]
+}
+
+// invalidate drops the collection's metadata in every scope, so the next
+// lookup in each scope fetches it again, and forgets its default metric in
+// scope, where it was created or deleted.
+func (cache *metadataCache) invalidate(scope string, name string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for key := range cache.entries {
+		if key.name == name {
+			delete(cache.entries, key)
+		}
+	}
+	delete(cache.metrics, metadataKey{scope, name})
+}
+
+// collectionMetadata returns the collection from the metadata cache, fetching
+// it with GetCollection on a miss.
+func (c *Client) collectionMetadata(ctx context.Context, collection string, opts ...CallOption) (CollectionResponse, error) {
+	name := strings.TrimSpace(collection)
+	scope, scoped := c.metadataScope(ctx, opts)
+	if cached, found := c.metadata.get(scope, name); scoped && found {
+		return cached, nil
+	}
+	response, err := c.GetCollection(ctx, name, opts...)
+	if err != nil {
+		return CollectionResponse{}, err
+	}
+	if scoped {
+		c.metadata.put(scope, name, response)
+	}
+	return response, nil
+}
+
+// metadataScope returns the metadata cache scope for a call with opts, or
+// false when it cannot be determined and the cache must be bypassed.
+func (c *Client) metadataScope(ctx context.Context, opts []CallOption) (string, bool) {
+	return c.hashedRequestScope(ctx, newCallConfig(opts))
+}
+
+// hashedRequestScope is requestScope hashed to a fixed-size cache key.
+func (c *Client) hashedRequestScope(ctx context.Context, call *callConfig) (string, bool) {
+	scope, ok := c.requestScope(ctx, call)
+	if !ok {
+		return "", false
+	}
+	sum := sha256.Sum256(scope)
+	return string(sum[:]), true
+}
+
+func (c *Client) invalidateMetadata(ctx context.Context, collection string, opts []CallOption) {
+	scope, _ := c.metadataScope(ctx, opts)
+	c.metadata.invalidate(scope, strings.TrimSpace(collection))
+}
+
+func (c *Client) dimensionOf(ctx context.Context, collection string, opts ...CallOption) (int, error) {
+	metadata, err := c.collectionMetadata(ctx, collection, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return metadata.Dimension, nil
+}
+
+// Dimension returns the vector dimension of collection. Lookups are cached for
+// ClientOptions.MetadataCacheTTL (default 30s); a missing collection returns
+// the 404 *Error and is not cached.
+func (c *Client) Dimension(ctx context.Context, collection string, opts ...CallOption) (int, error) {
+	return c.dimensionOf(ctx, collection, opts...)
+}
@@ -0,0 +1,82 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDimensionCachesCollectionLookups(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		calls.Add(1)
+		if request.URL.Path != "/collections/demo" {
+			writer.WriteHeader(http.StatusNotFound)
+			writeJSON(t, writer, map[string]any{"error": "collection not found"})
+			return
+		}
+		writeJSON(t, writer, map[string]any{"name": "demo", "dimension": 384, "strict_finite": true, "point_count": 0})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{MetadataCacheTTL: time.Minute})
+	for attempt := 0; attempt < 3; attempt++ {
+		dimension, err := client.Dimension(context.Background(), "demo")
+		if err != nil {
+			t.Fatalf("dimension lookup failed: %v", err)
+		}
+		if dimension != 384 {
+			t.Fatalf("unexpected dimension: %d", dimension)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected one HTTP call for repeated lookups, got %d", calls.Load())
+	}
+
+	_, err := client.Dimension(context.Background(), "missing")
+	var requestErr *Error
+	if !errors.As(err, &requestErr) || requestErr.Status != http.StatusNotFound {
+		t.Fatalf("expected 404 error for missing collection, got: %v", err)
+	}
+	if _, err := client.Dimension(context.Background(), "missing"); err == nil {
+		t.Fatal("expected missing collection to stay an error")
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected failed lookups not to be cached, got %d calls", calls.Load())
+	}
+}
+
+func TestDimensionCacheIsScopedPerTenant(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		calls.Add(1)
+		dimension := 2
+		if request.Header.Get("X-Tenant") == "b" {
+			dimension = 3
+		}
+		writeJSON(t, writer, map[string]any{"name": "demo", "dimension": dimension, "point_count": 0})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{MetadataCacheTTL: time.Minute})
+	tenantB := WithContextHeaders(context.Background(), map[string]string{"X-Tenant": "b"})
+	for range 2 {
+		if dimension, err := client.Dimension(context.Background(), "demo", WithHeader("X-Tenant", "a")); err != nil || dimension != 2 {
+			t.Fatalf("tenant a: dimension %d, err %v", dimension, err)
+		}
+		if dimension, err := client.Dimension(tenantB, "demo"); err != nil || dimension != 3 {
+			t.Fatalf("tenant b: dimension %d, err %v", dimension, err)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected one lookup per tenant, got %d", calls.Load())
+	}
+}
@@ -0,0 +1,18 @@
+package aionbd
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// UnmarshalJSON lowercases and trims metric names so responses from servers
+// that spell them differently ("DOT", " l2") still compare equal to the
+// Metric constants. Unknown names are kept, normalized the same way.
+func (m *Metric) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*m = Metric(strings.ToLower(strings.TrimSpace(value)))
+	return nil
+}
@@ -0,0 +1,25 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResponseMetricIsNormalized(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writeJSON(t, writer, map[string]any{"metric": " DOT ", "value": 1.0})
+	}))
+	defer server.Close()
+
+	response, err := NewClient(server.URL, nil).Distance(context.Background(), []float32{1}, []float32{1}, MetricDot)
+	if err != nil {
+		t.Fatalf("Distance returned error: %v", err)
+	}
+	if response.Metric != MetricDot {
+		t.Fatalf("expected %q, got %q", MetricDot, response.Metric)
+	}
+}
@@ -0,0 +1,51 @@
+package aionbd
+
+// counters lists the monotonic counters of m; gauges such as in-flight
+// requests or sizes are left out because they can legitimately shrink.
+func (m MetricsResponse) counters() []uint64 {
+	return []uint64{
+		m.HTTPRequestsTotal,
+		m.HTTPResponses2xxTotal,
+		m.HTTPResponses4xxTotal,
+		m.HTTPRequests5xxTotal,
+		m.HTTPRequestDurationUsTotal,
+		m.L2IndexCacheLookups,
+		m.L2IndexCacheHits,
+		m.L2IndexCacheMisses,
+		m.L2IndexBuildRequests,
+		m.L2IndexBuildSuccesses,
+		m.L2IndexBuildFailures,
+		m.L2IndexBuildCooldownSkips,
+		m.AuthFailuresTotal,
+		m.RateLimitRejectionsTotal,
+		m.AuditEventsTotal,
+		m.TenantQuotaCollectionRejectionsTotal,
+		m.TenantQuotaPointRejectionsTotal,
+		m.PersistenceWrites,
+		m.PersistenceCheckpointDegradedTotal,
+		m.PersistenceCheckpointSuccessTotal,
+		m.PersistenceCheckpointErrorTotal,
+		m.PersistenceCheckpointScheduleSkipsTotal,
+		m.PersistenceWALGroupCommitsTotal,
+		m.PersistenceWALGroupedRecordsTotal,
+		m.SearchQueriesTotal,
+		m.SearchIVFQueriesTotal,
+		m.SearchIVFFallbackExactTotal,
+	}
+}
+
+// DetectReset reports whether the server restarted or reset its counters
+// between prev and m: uptime went down or any counter went backwards. Rate
+// calculations spanning the two scrapes should be restarted from m.
+func (m MetricsResponse) DetectReset(prev MetricsResponse) bool {
+	if m.UptimeMS < prev.UptimeMS {
+		return true
+	}
+	current := m.counters()
+	for index, previous := range prev.counters() {
+		if current[index] < previous {
+			return true
+		}
+	}
+	return false
+}
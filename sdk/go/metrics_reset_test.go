@@ -0,0 +1,31 @@
+package aionbd
+
+import "testing"
+
+func TestDetectResetOnUptimeOrCounterDrop(t *testing.T) {
+	t.Parallel()
+
+	prev := MetricsResponse{UptimeMS: 60_000, HTTPRequestsTotal: 100, SearchQueriesTotal: 40, HTTPRequestsInFlight: 5}
+	next := prev
+	next.UptimeMS = 65_000
+	next.HTTPRequestsTotal = 120
+	next.HTTPRequestsInFlight = 0
+	if next.DetectReset(prev) {
+		t.Fatal("expected no reset for growing counters and a shrinking gauge")
+	}
+	if next.DetectReset(MetricsResponse{}) {
+		t.Fatal("expected no reset against an empty first scrape")
+	}
+
+	restarted := MetricsResponse{UptimeMS: 1_000, HTTPRequestsTotal: 200, SearchQueriesTotal: 50}
+	if !restarted.DetectReset(next) {
+		t.Fatal("expected a reset when uptime decreases")
+	}
+
+	reset := next
+	reset.UptimeMS = 70_000
+	reset.SearchQueriesTotal = 3
+	if !reset.DetectReset(next) {
+		t.Fatal("expected a reset when a counter goes backwards")
+	}
+}
@@ -0,0 +1,97 @@
+package aionbd
+
+type PersistenceWALMetrics struct {
+	SyncOnWrite             bool
+	SyncEveryNWrites        uint64
+	SyncIntervalSeconds     uint64
+	GroupCommitMaxBatch     int
+	GroupCommitFlushDelayMS uint64
+	GroupCommitsTotal       uint64
+	GroupedRecordsTotal     uint64
+	GroupQueueDepth         int
+	SizeBytes               uint64
+	TailOpen                bool
+}
+
+type PersistenceCheckpointMetrics struct {
+	Async              bool
+	CompactAfter       int
+	InFlight           bool
+	DegradedTotal      uint64
+	SuccessTotal       uint64
+	ErrorTotal         uint64
+	ScheduleSkipsTotal uint64
+}
+
+type PersistenceIncrementalMetrics struct {
+	Segments  uint64
+	SizeBytes uint64
+}
+
+type PersistenceMetrics struct {
+	Enabled     bool
+	Writes      uint64
+	WAL         PersistenceWALMetrics
+	Checkpoints PersistenceCheckpointMetrics
+	Incremental PersistenceIncrementalMetrics
+}
+
+func (m MetricsResponse) Persistence() PersistenceMetrics {
+	return PersistenceMetrics{
+		Enabled: m.PersistenceEnabled,
+		Writes:  m.PersistenceWrites,
+		WAL: PersistenceWALMetrics{
+			SyncOnWrite:             m.PersistenceWALSyncOnWrite,
+			SyncEveryNWrites:        m.PersistenceWALSyncEveryNWrites,
+			SyncIntervalSeconds:     m.PersistenceWALSyncIntervalSeconds,
+			GroupCommitMaxBatch:     m.PersistenceWALGroupCommitMaxBatch,
+			GroupCommitFlushDelayMS: m.PersistenceWALGroupCommitFlushDelayMS,
+			GroupCommitsTotal:       m.PersistenceWALGroupCommitsTotal,
+			GroupedRecordsTotal:     m.PersistenceWALGroupedRecordsTotal,
+			GroupQueueDepth:         m.PersistenceWALGroupQueueDepth,
+			SizeBytes:               m.PersistenceWALSizeBytes,
+			TailOpen:                m.PersistenceWALTailOpen,
+		},
+		Checkpoints: PersistenceCheckpointMetrics{
+			Async:              m.PersistenceAsyncCheckpoints,
+			CompactAfter:       m.PersistenceCheckpointCompactAfter,
+			InFlight:           m.PersistenceCheckpointInFlight,
+			DegradedTotal:      m.PersistenceCheckpointDegradedTotal,
+			SuccessTotal:       m.PersistenceCheckpointSuccessTotal,
+			ErrorTotal:         m.PersistenceCheckpointErrorTotal,
+			ScheduleSkipsTotal: m.PersistenceCheckpointScheduleSkipsTotal,
+		},
+		Incremental: PersistenceIncrementalMetrics{
+			Segments:  m.PersistenceIncrementalSegments,
+			SizeBytes: m.PersistenceIncrementalSizeBytes,
+		},
+	}
+}
+
+// Healthy reports false once any checkpoint has failed or degraded. The server
+// counters start at zero on boot, so any non-zero value means they grew since
+// the process started.
+func (p PersistenceMetrics) Healthy() bool {
+	return p.Checkpoints.ErrorTotal == 0 && p.Checkpoints.DegradedTotal == 0
+}
+
+type TenantQuotaMetrics struct {
+	RateWindowEntries         int
+	QuotaLockEntries          int
+	CollectionRejectionsTotal uint64
+	PointRejectionsTotal      uint64
+}
+
+func (m MetricsResponse) TenantQuotas() TenantQuotaMetrics {
+	return TenantQuotaMetrics{
+		RateWindowEntries:         m.TenantRateWindowEntries,
+		QuotaLockEntries:          m.TenantQuotaLockEntries,
+		CollectionRejectionsTotal: m.TenantQuotaCollectionRejectionsTotal,
+		PointRejectionsTotal:      m.TenantQuotaPointRejectionsTotal,
+	}
+}
+
+// RejectionsTotal sums the collection and point quota rejections.
+func (q TenantQuotaMetrics) RejectionsTotal() uint64 {
+	return q.CollectionRejectionsTotal + q.PointRejectionsTotal
+}
@@ -0,0 +1,62 @@
+package aionbd
+
+import "testing"
+
+func TestPersistenceMetricsGroupsFieldsAndDerivesHealth(t *testing.T) {
+	t.Parallel()
+
+	metrics := MetricsResponse{
+		PersistenceEnabled:                true,
+		PersistenceWrites:                 42,
+		PersistenceWALSizeBytes:           4096,
+		PersistenceWALGroupQueueDepth:     3,
+		PersistenceCheckpointSuccessTotal: 5,
+		PersistenceIncrementalSegments:    2,
+	}
+
+	persistence := metrics.Persistence()
+	if !persistence.Enabled || persistence.Writes != 42 {
+		t.Fatalf("unexpected top-level fields: %#v", persistence)
+	}
+	if persistence.WAL.SizeBytes != 4096 || persistence.WAL.GroupQueueDepth != 3 {
+		t.Fatalf("unexpected wal group: %#v", persistence.WAL)
+	}
+	if persistence.Checkpoints.SuccessTotal != 5 || persistence.Incremental.Segments != 2 {
+		t.Fatalf("unexpected checkpoint/incremental groups: %#v", persistence)
+	}
+	if !persistence.Healthy() {
+		t.Fatal("expected healthy persistence")
+	}
+
+	metrics.PersistenceCheckpointErrorTotal = 1
+	if metrics.Persistence().Healthy() {
+		t.Fatal("expected checkpoint errors to mark persistence unhealthy")
+	}
+
+	metrics.PersistenceCheckpointErrorTotal = 0
+	metrics.PersistenceCheckpointDegradedTotal = 1
+	if metrics.Persistence().Healthy() {
+		t.Fatal("expected degraded checkpoints to mark persistence unhealthy")
+	}
+}
+
+func TestTenantQuotaMetricsGroupsFieldsAndSumsRejections(t *testing.T) {
+	t.Parallel()
+
+	quotas := MetricsResponse{
+		TenantRateWindowEntries:              7,
+		TenantQuotaLockEntries:               2,
+		TenantQuotaCollectionRejectionsTotal: 3,
+		TenantQuotaPointRejectionsTotal:      11,
+	}.TenantQuotas()
+
+	if quotas.RateWindowEntries != 7 || quotas.QuotaLockEntries != 2 {
+		t.Fatalf("unexpected entry gauges: %#v", quotas)
+	}
+	if quotas.CollectionRejectionsTotal != 3 || quotas.PointRejectionsTotal != 11 {
+		t.Fatalf("unexpected rejection counters: %#v", quotas)
+	}
+	if total := quotas.RejectionsTotal(); total != 14 {
+		t.Fatalf("expected 14 rejections, got %d", total)
+	}
+}
@@ -0,0 +1,140 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// nodeDownCooldown is how long a node that refused a connection is tried only
+// after the healthy ones.
+const nodeDownCooldown = 5 * time.Second
+
+// nodePool round-robins requests over the base URLs of a pooled client.
+type nodePool struct {
+	nodes []string
+	next  atomic.Uint64
+
+	mu        sync.Mutex
+	downUntil map[string]time.Time
+}
+
+func newNodePool(nodes []string) *nodePool {
+	return &nodePool{nodes: nodes, downUntil: make(map[string]time.Time)}
+}
+
+// order returns every node, starting at the next round-robin position, with
+// nodes in their down cooldown moved to the end.
+func (pool *nodePool) order(now time.Time) []string {
+	start := int((pool.next.Add(1) - 1) % uint64(len(pool.nodes)))
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	ordered := make([]string, 0, len(pool.nodes))
+	var down []string
+	for offset := range pool.nodes {
+		node := pool.nodes[(start+offset)%len(pool.nodes)]
+		if now.Before(pool.downUntil[node]) {
+			down = append(down, node)
+			continue
+		}
+		ordered = append(ordered, node)
+	}
+	return append(ordered, down...)
+}
+
+func (pool *nodePool) markDown(node string, now time.Time) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.downUntil[node] = now.Add(nodeDownCooldown)
+}
+
+func (pool *nodePool) markUp(node string) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	delete(pool.downUntil, node)
+}
+
+// NewPooledClient returns a client that spreads requests round-robin over
+// several AIONBD nodes. A request whose connection is refused fails over to
+// the next node; the refusing node is then tried last for 5s. Only dial
+// failures fail over, since the request never reached the node. Use WithNode
+// to pin a call, such as a health check, to one node.
+func NewPooledClient(baseURLs []string, options *ClientOptions) *Client {
+	nodes := make([]string, 0, len(baseURLs))
+	for _, baseURL := range baseURLs {
+		if baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/"); baseURL != "" {
+			nodes = append(nodes, baseURL)
+		}
+	}
+	if len(nodes) == 0 {
+		client := NewClient("", options)
+		if client.configErr == nil {
+			client.configErr = fmt.Errorf("pooled client needs at least one base URL")
+		}
+		return client
+	}
+	client := NewClient(nodes[0], options)
+	for _, node := range nodes {
+		if _, isUnix := unixSocketPath(node); isUnix && client.configErr == nil {
+			client.configErr = fmt.Errorf("pooled client does not support unix socket base URLs")
+		}
+	}
+	client.pool = newNodePool(nodes)
+	return client
+}
+
+// WithNode sends this call to the given base URL instead of the client's base
+// URL or pool, without failover.
+func WithNode(baseURL string) CallOption {
+	return func(call *callConfig) {
+		call.node = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	}
+}
+
+// nodes returns the base URLs to try for a call, in order.
+func (c *Client) nodes(call *callConfig) []string {
+	if call.node != "" {
+		return []string{call.node}
+	}
+	if c.pool == nil {
+		return []string{c.baseURL}
+	}
+	return c.pool.order(time.Now())
+}
+
+func (c *Client) sendRequestToNodes(ctx context.Context, method string, path string, encoded []byte, contentEncoding string, hasBody bool, raw bool, call *callConfig) ([]byte, error) {
+	if c.pool == nil || call.node != "" {
+		return c.sendRequest(ctx, c.nodes(call)[0], method, path, encoded, contentEncoding, hasBody, raw, call)
+	}
+	if current := sessionFrom(ctx); current != nil {
+		return c.sendRequestInSession(ctx, current, method, path, encoded, contentEncoding, hasBody, raw, call)
+	}
+	payload, _, err := c.sendRequestFailover(ctx, method, path, encoded, contentEncoding, hasBody, raw, call)
+	return payload, err
+}
+
+// sendRequestFailover tries the pool's nodes in order until one accepts the
+// connection, and reports which node that was.
+func (c *Client) sendRequestFailover(ctx context.Context, method string, path string, encoded []byte, contentEncoding string, hasBody bool, raw bool, call *callConfig) ([]byte, string, error) {
+	var err error
+	for _, node := range c.pool.order(time.Now()) {
+		var payload []byte
+		payload, err = c.sendRequest(ctx, node, method, path, encoded, contentEncoding, hasBody, raw, call)
+		if !isDialError(err) {
+			c.pool.markUp(node)
+			return payload, node, err
+		}
+		c.pool.markDown(node, time.Now())
+	}
+	return nil, "", err
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
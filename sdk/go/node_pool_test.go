@@ -0,0 +1,60 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPooledClientDistributesRequestsAndFailsOver(t *testing.T) {
+	t.Parallel()
+
+	var hitsA, hitsB atomic.Int32
+	nodeA := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		hitsA.Add(1)
+		writeJSON(t, writer, map[string]any{"status": "live"})
+	}))
+	defer nodeA.Close()
+	nodeB := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		hitsB.Add(1)
+		writeJSON(t, writer, map[string]any{"status": "live"})
+	}))
+	defer nodeB.Close()
+	dead := httptest.NewServer(http.NotFoundHandler())
+	deadURL := dead.URL
+	dead.Close()
+
+	client := NewPooledClient([]string{nodeA.URL, deadURL, nodeB.URL + "/"}, nil)
+	ctx := context.Background()
+	for range 9 {
+		if _, err := client.Live(ctx); err != nil {
+			t.Fatalf("Live returned error: %v", err)
+		}
+	}
+	if hitsA.Load() == 0 || hitsB.Load() == 0 || hitsA.Load()+hitsB.Load() != 9 {
+		t.Fatalf("expected requests spread over both live nodes, got a=%d b=%d", hitsA.Load(), hitsB.Load())
+	}
+
+	before := hitsB.Load()
+	for range 3 {
+		if _, err := client.Live(ctx, WithNode(nodeB.URL)); err != nil {
+			t.Fatalf("Live with WithNode returned error: %v", err)
+		}
+	}
+	if hitsB.Load() != before+3 {
+		t.Fatalf("expected WithNode to pin calls to node b, got %d new hits", hitsB.Load()-before)
+	}
+	if _, err := client.Live(ctx, WithNode(deadURL)); err == nil {
+		t.Fatal("expected WithNode on a dead node to fail without failover")
+	}
+}
+
+func TestNewPooledClientRequiresBaseURLs(t *testing.T) {
+	t.Parallel()
+
+	if err := NewPooledClient([]string{" ", ""}, nil).ConfigError(); err == nil {
+		t.Fatal("expected a configuration error for an empty pool")
+	}
+}
@@ -0,0 +1,44 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const operationIDHeader = "X-Operation-ID"
+
+type operationObserverKey struct{}
+
+// WithOperationObserver returns a context whose requests report the server
+// operation ID of every response carrying an X-Operation-ID header to fn, so
+// long-running helpers such as ImportCollection or CopyPoints expose the
+// operations they start and the caller can CancelOperation them out of band.
+// fn may be called concurrently and more than once per ID.
+func WithOperationObserver(ctx context.Context, fn func(opID string)) context.Context {
+	return context.WithValue(ctx, operationObserverKey{}, fn)
+}
+
+func observeOperationID(ctx context.Context, header http.Header) {
+	fn, _ := ctx.Value(operationObserverKey{}).(func(string))
+	if fn == nil {
+		return
+	}
+	if opID := header.Get(operationIDHeader); opID != "" {
+		fn(opID)
+	}
+}
+
+// CancelOperation asks the server to cancel the long-running operation opID,
+// as reported through WithOperationObserver.
+func (c *Client) CancelOperation(ctx context.Context, opID string, opts ...CallOption) error {
+	opID = strings.TrimSpace(opID)
+	if opID == "" {
+		return fmt.Errorf("operation id must not be empty")
+	}
+	path := fmt.Sprintf("/operations/%s/cancel", url.PathEscape(opID))
+	_, err := c.requestJSONOrEmpty(ctx, http.MethodPost, path, nil, &struct{}{}, opts...)
+	return err
+}
@@ -0,0 +1,61 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestImportExposesOperationIDAndCancelTargetsIt(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var cancelPath string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if strings.HasPrefix(request.URL.Path, "/operations/") {
+			mu.Lock()
+			cancelPath = request.URL.EscapedPath()
+			mu.Unlock()
+			if request.Method != http.MethodPost {
+				t.Errorf("expected POST, got %s", request.Method)
+			}
+			writer.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writer.Header().Set("X-Operation-ID", "import/42")
+		writeJSON(t, writer, map[string]any{"created": 1, "updated": 0})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	var seen []string
+	ctx := WithOperationObserver(context.Background(), func(opID string) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, opID)
+	})
+	if _, err := client.ImportCollection(ctx, "demo", strings.NewReader(`{"id":1,"values":[1]}`+"\n"), 10); err != nil {
+		t.Fatalf("ImportCollection returned error: %v", err)
+	}
+	mu.Lock()
+	if len(seen) == 0 || seen[0] != "import/42" {
+		t.Fatalf("expected the server operation id to be observed, got %q", seen)
+	}
+	opID := seen[0]
+	mu.Unlock()
+
+	if err := client.CancelOperation(context.Background(), opID); err != nil {
+		t.Fatalf("CancelOperation returned error: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if cancelPath != "/operations/import%2F42/cancel" {
+		t.Fatalf("unexpected cancel path %q", cancelPath)
+	}
+	if err := client.CancelOperation(context.Background(), " "); err == nil {
+		t.Fatal("expected an error for an empty operation id")
+	}
+}
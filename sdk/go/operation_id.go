@@ -0,0 +1,50 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type operationIDKey struct{}
+
+// WithOperationID tags a multi-request operation with id. UpsertPointsChunked
+// and ImportCollection then send each chunk with an Idempotency-Key of
+// "<id>-<chunkIndex>", so retrying the whole operation with the same id
+// reuses the same keys and lets the server drop chunks it already applied.
+// A chunk the client splits further (MaxBatchItems auto-chunking, 413
+// halving, or RetryFailedItems bisection) sends a distinct key per request.
+func WithOperationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, operationIDKey{}, id)
+}
+
+// chunkIdempotencyKey returns the key for chunk index of the operation in ctx,
+// or "" when ctx carries no operation ID.
+func chunkIdempotencyKey(ctx context.Context, index int) string {
+	id, _ := ctx.Value(operationIDKey{}).(string)
+	if id == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", id, index)
+}
+
+// withIdempotencyKey appends a call option sending key, without touching the
+// caller's slice. An empty key leaves opts as they are.
+func withIdempotencyKey(opts []CallOption, key string) []CallOption {
+	if key == "" {
+		return opts
+	}
+	return append(opts[:len(opts):len(opts)], withRequestHeader(idempotencyKeyHeader, key))
+}
+
+// withSubIdempotencyKey gives one part of a request that is split internally
+// its own key, "<key>-<part>", so a server honouring the key does not drop
+// the other parts as replays. Without a key opts are returned as they are.
+func withSubIdempotencyKey(opts []CallOption, part string) []CallOption {
+	key := newCallConfig(opts).header.Get(idempotencyKeyHeader)
+	if key == "" {
+		return opts
+	}
+	return withIdempotencyKey(opts, key+"-"+part)
+}
@@ -0,0 +1,98 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWithOperationIDDerivesStableChunkKeys(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mu.Lock()
+		keys = append(keys, request.Header.Get("Idempotency-Key"))
+		mu.Unlock()
+		writeJSON(t, writer, map[string]any{"created": 1, "updated": 0, "results": []any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	points := []UpsertPointsBatchItem{{ID: 1, Values: []float32{1}}, {ID: 2, Values: []float32{2}}, {ID: 3, Values: []float32{3}}}
+	ctx := WithOperationID(context.Background(), "load-42")
+	for range 2 {
+		if _, err := client.UpsertPointsChunked(ctx, "demo", points, 1); err != nil {
+			t.Fatalf("UpsertPointsChunked returned error: %v", err)
+		}
+	}
+	want := []string{"load-42-0", "load-42-1", "load-42-2", "load-42-0", "load-42-1", "load-42-2"}
+	if strings.Join(keys, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected keys %v, got %v", want, keys)
+	}
+
+	keys = nil
+	lines := `{"id":1,"values":[1]}` + "\n" + `{"id":2,"values":[2]}` + "\n"
+	if _, err := client.ImportCollection(ctx, "demo", strings.NewReader(lines), 1); err != nil {
+		t.Fatalf("ImportCollection returned error: %v", err)
+	}
+	if strings.Join(keys, ",") != "load-42-0,load-42-1" {
+		t.Fatalf("unexpected import keys: %v", keys)
+	}
+
+	keys = nil
+	if _, err := client.UpsertPointsChunked(context.Background(), "demo", points, 3); err != nil {
+		t.Fatalf("UpsertPointsChunked returned error: %v", err)
+	}
+	if len(keys) != 1 || keys[0] != "" {
+		t.Fatalf("expected no key without an operation id, got %v", keys)
+	}
+}
+
+func TestWithOperationIDKeysEachInternallySplitRequest(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	keys := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body struct {
+			Points []UpsertPointsBatchItem `json:"points"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode upsert body: %v", err)
+		}
+		if len(body.Points) > 1 {
+			writer.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		mu.Lock()
+		keys[request.Header.Get("Idempotency-Key")]++
+		mu.Unlock()
+		writeJSON(t, writer, map[string]any{"created": 1, "updated": 0, "results": []any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{MaxBatchItems: 2, AutoChunkBatches: true})
+	points := []UpsertPointsBatchItem{{ID: 1, Values: []float32{1}}, {ID: 2, Values: []float32{2}}, {ID: 3, Values: []float32{3}}}
+	response, err := client.UpsertPointsChunked(WithOperationID(context.Background(), "load-7"), "demo", points, 3)
+	if err != nil {
+		t.Fatalf("UpsertPointsChunked returned error: %v", err)
+	}
+	if response.Created != 3 {
+		t.Fatalf("expected 3 created points, got %+v", response)
+	}
+	want := []string{"load-7-0-p0-s0", "load-7-0-p0-s1", "load-7-0-p1"}
+	if len(keys) != len(want) {
+		t.Fatalf("expected a distinct key per accepted request, got %v", keys)
+	}
+	for _, key := range want {
+		if keys[key] != 1 {
+			t.Fatalf("expected key %q once, got %v", key, keys)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrOperationTimeout = errors.New("operation timeout exceeded")
+
+// withOperationTimeout bounds a multi-request helper by
+// ClientOptions.OperationTimeout. The returned finish function releases the
+// context and marks errors caused by that deadline with ErrOperationTimeout,
+// so callers can tell them apart from a per-request Timeout.
+func (c *Client) withOperationTimeout(ctx context.Context) (context.Context, func(error) error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c.operationTimeout <= 0 {
+		return ctx, func(err error) error { return err }
+	}
+	operationCtx, cancel := context.WithTimeoutCause(ctx, c.operationTimeout, ErrOperationTimeout)
+	return operationCtx, func(err error) error {
+		timedOut := context.Cause(operationCtx) == ErrOperationTimeout
+		cancel()
+		if err != nil && timedOut && !errors.Is(err, ErrOperationTimeout) {
+			return fmt.Errorf("%w after %s: %w", ErrOperationTimeout, c.operationTimeout, err)
+		}
+		return err
+	}
+}
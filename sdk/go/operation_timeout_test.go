@@ -0,0 +1,56 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOperationTimeoutAbortsLongImport(t *testing.T) {
+	t.Parallel()
+
+	var batches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		batches.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		writeJSON(t, writer, map[string]any{"created": 1, "updated": 0, "results": []any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{
+		Timeout:          time.Second,
+		OperationTimeout: 70 * time.Millisecond,
+	})
+	lines := strings.Repeat(`{"id":1,"values":[1,2]}`+"\n", 50)
+	stats, err := client.ImportCollection(context.Background(), "demo", strings.NewReader(lines), 1)
+	if !errors.Is(err, ErrOperationTimeout) {
+		t.Fatalf("expected ErrOperationTimeout, got %v", err)
+	}
+	if stats.Batches >= 50 || batches.Load() >= 50 {
+		t.Fatalf("expected the import to stop early, got %d batches", stats.Batches)
+	}
+}
+
+func TestOperationTimeoutLeavesShortOperationsAlone(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writeJSON(t, writer, map[string]any{"created": 2, "updated": 0, "results": []any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{OperationTimeout: time.Second})
+	points := []UpsertPointsBatchItem{{ID: 1, Values: []float32{1}}, {ID: 2, Values: []float32{2}}}
+	response, err := client.UpsertPointsChunked(context.Background(), "demo", points, 2)
+	if err != nil {
+		t.Fatalf("UpsertPointsChunked returned error: %v", err)
+	}
+	if response.Created != 2 {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+}
@@ -0,0 +1,18 @@
+package aionbd
+
+import (
+	"net/url"
+	"strings"
+)
+
+// collectionPathSegment escapes a collection name for use as one path
+// segment. url.PathEscape already encodes "/", "?", "#", "%", spaces, and
+// non-ASCII bytes, but leaves "." and ".." alone, which proxies and servers
+// normalize away as relative segments; those are fully percent-encoded.
+func collectionPathSegment(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "." || name == ".." {
+		return strings.Repeat("%2E", len(name))
+	}
+	return url.PathEscape(name)
+}
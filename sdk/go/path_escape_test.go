@@ -0,0 +1,80 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCollectionPathsEscapeTrickyNames(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mu.Lock()
+		paths = append(paths, strings.SplitN(request.RequestURI, "?", 2)[0])
+		mu.Unlock()
+		writeJSON(t, writer, map[string]any{})
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, nil)
+	ctx := context.Background()
+
+	names := []struct {
+		name    string
+		segment string
+	}{
+		{"demo", "demo"},
+		{" padded ", "padded"},
+		{"my collection", "my%20collection"},
+		{"a/b", "a%2Fb"},
+		{"a+b", "a+b"},
+		{"résumé", "r%C3%A9sum%C3%A9"},
+		{"50%", "50%25"},
+		{"q?x#y", "q%3Fx%23y"},
+		{".", "%2E"},
+		{"..", "%2E%2E"},
+	}
+	methods := []struct {
+		name   string
+		suffix string
+		call   func(collection string)
+	}{
+		{"GetCollection", "", func(collection string) { client.GetCollection(ctx, collection) }},
+		{"DeleteCollection", "", func(collection string) { client.DeleteCollection(ctx, collection) }},
+		{"SearchCollection", "/search", func(collection string) { client.SearchCollection(ctx, collection, []float32{1}, nil) }},
+		{"SearchCollectionTopK", "/search/topk", func(collection string) { client.SearchCollectionTopK(ctx, collection, []float32{1}, nil) }},
+		{"SearchCollectionTopKBatch", "/search/topk/batch", func(collection string) {
+			client.SearchCollectionTopKBatch(ctx, collection, [][]float32{{1}}, nil)
+		}},
+		{"UpsertPoint", "/points/7", func(collection string) { client.UpsertPoint(ctx, collection, 7, []float32{1}, nil) }},
+		{"UpsertPointsBatch", "/points", func(collection string) {
+			client.UpsertPointsBatch(ctx, collection, []UpsertPointsBatchItem{{ID: 7, Values: []float32{1}}})
+		}},
+		{"GetPoint", "/points/7", func(collection string) { client.GetPoint(ctx, collection, 7) }},
+		{"DeletePoint", "/points/7", func(collection string) { client.DeletePoint(ctx, collection, 7) }},
+		{"ListPoints", "/points", func(collection string) { client.ListPoints(ctx, collection, nil) }},
+		{"GetPointsBatch", "/points/get", func(collection string) { client.GetPointsBatch(ctx, collection, []uint64{7}, nil) }},
+	}
+
+	for _, name := range names {
+		for _, method := range methods {
+			mu.Lock()
+			paths = nil
+			mu.Unlock()
+
+			method.call(name.name)
+
+			mu.Lock()
+			want := "/collections/" + name.segment + method.suffix
+			if len(paths) == 0 || paths[0] != want {
+				t.Errorf("%s(%q): expected request path %q, got %q", method.name, name.name, want, paths)
+			}
+			mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package aionbd
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// Typed payload getters return ok=false when the key is missing or the value
+// has another type. Numbers decoded from JSON arrive as float64; GetInt64
+// accepts them only when they hold an exact integer within int64 range.
+
+func (p PointPayload) GetString(key string) (string, bool) {
+	value, ok := p[key].(string)
+	return value, ok
+}
+
+func (p PointPayload) GetBool(key string) (bool, bool) {
+	value, ok := p[key].(bool)
+	return value, ok
+}
+
+func (p PointPayload) GetFloat64(key string) (float64, bool) {
+	switch value := p[key].(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case json.Number:
+		parsed, err := value.Float64()
+		return parsed, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func (p PointPayload) GetInt64(key string) (int64, bool) {
+	switch value := p[key].(type) {
+	case int:
+		return int64(value), true
+	case int64:
+		return value, true
+	case json.Number:
+		parsed, err := value.Int64()
+		return parsed, err == nil
+	case float64:
+		if value != math.Trunc(value) || value < math.MinInt64 || value >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(value), true
+	default:
+		return 0, false
+	}
+}
+
+func (p PointPayload) GetStringSlice(key string) ([]string, bool) {
+	switch value := p[key].(type) {
+	case []string:
+		return value, true
+	case []any:
+		values := make([]string, len(value))
+		for index, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			values[index] = text
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,45 @@
+package aionbd
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPointPayloadTypedGetters(t *testing.T) {
+	t.Parallel()
+
+	var payload PointPayload
+	raw := `{"name":"doc","count":42,"ratio":0.5,"draft":false,"tags":["a","b"],"mixed":["a",1]}`
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+
+	if value, ok := payload.GetString("name"); !ok || value != "doc" {
+		t.Fatalf("unexpected string: %q %v", value, ok)
+	}
+	if value, ok := payload.GetInt64("count"); !ok || value != 42 {
+		t.Fatalf("expected float64 -> int64 coercion, got: %d %v", value, ok)
+	}
+	if value, ok := payload.GetFloat64("ratio"); !ok || value != 0.5 {
+		t.Fatalf("unexpected float: %v %v", value, ok)
+	}
+	if value, ok := payload.GetBool("draft"); !ok || value {
+		t.Fatalf("unexpected bool: %v %v", value, ok)
+	}
+	if value, ok := payload.GetStringSlice("tags"); !ok || len(value) != 2 || value[1] != "b" {
+		t.Fatalf("unexpected string slice: %#v %v", value, ok)
+	}
+
+	if _, ok := payload.GetInt64("ratio"); ok {
+		t.Fatal("expected fractional number to fail int64 coercion")
+	}
+	if _, ok := payload.GetString("count"); ok {
+		t.Fatal("expected number to fail string getter")
+	}
+	if _, ok := payload.GetStringSlice("mixed"); ok {
+		t.Fatal("expected mixed array to fail string slice getter")
+	}
+	if _, ok := payload.GetBool("missing"); ok {
+		t.Fatal("expected missing key to report ok=false")
+	}
+}
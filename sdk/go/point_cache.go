@@ -0,0 +1,205 @@
+package aionbd
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPointCacheEntries = 1024
+	defaultPointCacheTTL     = 30 * time.Second
+)
+
+// PointCacheConfig enables an in-memory LRU read-through cache for GetPoint and
+// GetPointsBatch, keyed by collection and point ID within one set of
+// credentials and headers. Upserts and deletes made through the client drop
+// the affected entries; writes from elsewhere are only picked up once TTL
+// expires. Entries are copied in and out, so callers may modify the returned
+// points. WithNoCache and WithRawCapture bypass it.
+type PointCacheConfig struct {
+	MaxEntries int
+	TTL        time.Duration
+}
+
+type pointCacheKey struct {
+	collection string
+	id         uint64
+}
+
+type pointCacheEntry struct {
+	key       pointCacheKey
+	scope     string
+	point     PointResponse
+	expiresAt time.Time
+}
+
+// pointCache indexes entries by point first and scope second, so a write can
+// drop a point for every scope at once.
+type pointCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	entries    map[pointCacheKey]map[string]*list.Element
+	order      *list.List
+}
+
+func newPointCache(config *PointCacheConfig) *pointCache {
+	if config == nil {
+		return nil
+	}
+	cache := &pointCache{
+		maxEntries: config.MaxEntries,
+		ttl:        config.TTL,
+		entries:    make(map[pointCacheKey]map[string]*list.Element),
+		order:      list.New(),
+	}
+	if cache.maxEntries <= 0 {
+		cache.maxEntries = defaultPointCacheEntries
+	}
+	if cache.ttl <= 0 {
+		cache.ttl = defaultPointCacheTTL
+	}
+	return cache
+}
+
+// pointCacheScope returns the cache scope for a call, or false when the call
+// must bypass the cache.
+func (c *Client) pointCacheScope(ctx context.Context, call *callConfig) (string, bool) {
+	if c.pointCache == nil || call.noCache || call.capturesResponse() {
+		return "", false
+	}
+	return c.hashedRequestScope(ctx, call)
+}
+
+func (cache *pointCache) get(scope string, collection string, id uint64) (PointResponse, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	element, found := cache.entries[pointCacheKey{collection, id}][scope]
+	if !found {
+		return PointResponse{}, false
+	}
+	entry := element.Value.(*pointCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		cache.remove(element)
+		return PointResponse{}, false
+	}
+	cache.order.MoveToFront(element)
+	return copyPointResponse(entry.point), true
+}
+
+func (cache *pointCache) put(scope string, collection string, point PointResponse) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	key := pointCacheKey{collection, point.ID}
+	entry := &pointCacheEntry{
+		key:       key,
+		scope:     scope,
+		point:     copyPointResponse(point),
+		expiresAt: time.Now().Add(cache.ttl),
+	}
+	scopes := cache.entries[key]
+	if element, found := scopes[scope]; found {
+		element.Value = entry
+		cache.order.MoveToFront(element)
+		return
+	}
+	if scopes == nil {
+		scopes = make(map[string]*list.Element)
+		cache.entries[key] = scopes
+	}
+	scopes[scope] = cache.order.PushFront(entry)
+	for cache.order.Len() > cache.maxEntries {
+		cache.remove(cache.order.Back())
+	}
+}
+
+func (cache *pointCache) invalidate(collection string, ids ...uint64) {
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for _, id := range ids {
+		for _, element := range cache.entries[pointCacheKey{collection, id}] {
+			cache.remove(element)
+		}
+	}
+}
+
+func (cache *pointCache) invalidateItems(collection string, points []UpsertPointsBatchItem) {
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for _, point := range points {
+		for _, element := range cache.entries[pointCacheKey{collection, point.ID}] {
+			cache.remove(element)
+		}
+	}
+}
+
+func (cache *pointCache) invalidateCollection(collection string) {
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for key, scopes := range cache.entries {
+		if key.collection != collection {
+			continue
+		}
+		for _, element := range scopes {
+			cache.remove(element)
+		}
+	}
+}
+
+// remove drops one entry; the caller holds the lock.
+func (cache *pointCache) remove(element *list.Element) {
+	entry := element.Value.(*pointCacheEntry)
+	cache.order.Remove(element)
+	scopes := cache.entries[entry.key]
+	delete(scopes, entry.scope)
+	if len(scopes) == 0 {
+		delete(cache.entries, entry.key)
+	}
+}
+
+func copyPointResponse(point PointResponse) PointResponse {
+	if point.Values != nil {
+		point.Values = append([]float32(nil), point.Values...)
+	}
+	if point.Payload != nil {
+		point.Payload = copyPayloadValue(map[string]any(point.Payload)).(map[string]any)
+	}
+	return point
+}
+
+// copyPayloadValue deep-copies the maps and slices that decoded JSON is made
+// of; scalars are returned as they are.
+func copyPayloadValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(typed))
+		for key, item := range typed {
+			copied[key] = copyPayloadValue(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(typed))
+		for index, item := range typed {
+			copied[index] = copyPayloadValue(item)
+		}
+		return copied
+	default:
+		return value
+	}
+}
@@ -0,0 +1,62 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPointCacheServesHitsAndInvalidatesOnDelete(t *testing.T) {
+	t.Parallel()
+
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.Method {
+		case http.MethodGet:
+			gets.Add(1)
+			writeJSON(t, writer, map[string]any{"id": 7, "values": []float32{1, 2}, "payload": map[string]any{"tags": []any{"a"}}})
+		case http.MethodDelete:
+			writeJSON(t, writer, map[string]any{"id": 7, "deleted": true})
+		default:
+			t.Errorf("unexpected method %s", request.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{PointCache: &PointCacheConfig{}})
+	ctx := context.Background()
+	first, err := client.GetPoint(ctx, "demo", 7)
+	if err != nil {
+		t.Fatalf("GetPoint returned error: %v", err)
+	}
+	first.Values[0] = 99
+	first.Payload["tags"].([]any)[0] = "mutated"
+
+	second, err := client.GetPoint(ctx, "demo", 7)
+	if err != nil {
+		t.Fatalf("GetPoint returned error: %v", err)
+	}
+	if got := gets.Load(); got != 1 {
+		t.Fatalf("expected one network read, got %d", got)
+	}
+	if second.Values[0] != 1 || second.Payload["tags"].([]any)[0] != "a" {
+		t.Fatalf("cached point was mutated through a returned copy: %+v", second)
+	}
+
+	batch, err := client.GetPointsBatch(ctx, "demo", []uint64{7}, nil)
+	if err != nil || len(batch) != 1 || gets.Load() != 1 {
+		t.Fatalf("expected GetPointsBatch to hit the cache, got %+v, err %v, reads %d", batch, err, gets.Load())
+	}
+
+	if _, err := client.DeletePoint(ctx, "demo", 7); err != nil {
+		t.Fatalf("DeletePoint returned error: %v", err)
+	}
+	if _, err := client.GetPoint(ctx, "demo", 7); err != nil {
+		t.Fatalf("GetPoint returned error: %v", err)
+	}
+	if got := gets.Load(); got != 2 {
+		t.Fatalf("expected delete to invalidate the entry, got %d reads", got)
+	}
+}
@@ -0,0 +1,34 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PointExists reports whether pointID is stored in collection using a HEAD
+// request, so the vector is never transferred. A 404 (missing point or
+// collection) maps to false. Servers that reject HEAD with 405 or 501 are
+// remembered and queried with GET instead.
+func (c *Client) PointExists(ctx context.Context, collection string, pointID uint64, opts ...CallOption) (bool, error) {
+	if !c.capabilities.supported(capabilityHeadPoint) {
+		return c.pointExistsByGet(ctx, collection, pointID, opts...)
+	}
+
+	path := fmt.Sprintf(pointPathFormat, collectionPathSegment(collection), pointID)
+	err := c.requestJSON(ctx, http.MethodHead, path, nil, &struct{}{}, opts...)
+	var requestErr *Error
+	switch {
+	case err == nil:
+		c.capabilities.markSupported(capabilityHeadPoint)
+		return true, nil
+	case errors.As(err, &requestErr) && requestErr.Status == http.StatusNotFound:
+		return false, nil
+	case errors.As(err, &requestErr) && (requestErr.Status == http.StatusMethodNotAllowed || requestErr.Status == http.StatusNotImplemented):
+		c.capabilities.markUnsupported(capabilityHeadPoint)
+		return c.pointExistsByGet(ctx, collection, pointID, opts...)
+	default:
+		return false, err
+	}
+}
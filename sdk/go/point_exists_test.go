@@ -0,0 +1,68 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPointExistsUsesHead(t *testing.T) {
+	t.Parallel()
+
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		methods = append(methods, request.Method)
+		if request.URL.Path == "/collections/demo/points/404" {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	exists, err := client.PointExists(context.Background(), "demo", 1)
+	if err != nil || !exists {
+		t.Fatalf("expected point 1 to exist, got %v %v", exists, err)
+	}
+	missing, err := client.PointExists(context.Background(), "demo", 404)
+	if err != nil || missing {
+		t.Fatalf("expected 404 to map to false, got %v %v", missing, err)
+	}
+	if len(methods) != 2 || methods[0] != http.MethodHead || methods[1] != http.MethodHead {
+		t.Fatalf("expected HEAD requests, got: %v", methods)
+	}
+}
+
+func TestPointExistsFallsBackToGet(t *testing.T) {
+	t.Parallel()
+
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		methods = append(methods, request.Method)
+		if request.Method == http.MethodHead {
+			writer.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(t, writer, map[string]any{"id": 1, "values": []float32{1}, "payload": map[string]any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	for attempt := 0; attempt < 2; attempt++ {
+		exists, err := client.PointExists(context.Background(), "demo", 1)
+		if err != nil || !exists {
+			t.Fatalf("expected fallback to find the point, got %v %v", exists, err)
+		}
+	}
+	expected := []string{http.MethodHead, http.MethodGet, http.MethodGet}
+	if len(methods) != len(expected) {
+		t.Fatalf("unexpected methods: %v", methods)
+	}
+	for index := range expected {
+		if methods[index] != expected[index] {
+			t.Fatalf("unexpected methods: %v", methods)
+		}
+	}
+}
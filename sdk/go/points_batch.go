@@ -0,0 +1,127 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type GetPointsBatchOptions struct {
+	// IncludeValues defaults to true; false leaves every PointResponse.Values
+	// nil to keep responses small.
+	IncludeValues *bool
+}
+
+type getPointsBatchResponse struct {
+	Points []PointResponse `json:"points"`
+}
+
+// GetPointsBatch fetches several points in one call via
+// POST /collections/{name}/points/get with {"ids", "include_values"}. Missing
+// points are skipped, so the result may be shorter than ids. Servers without
+// the endpoint (404/405) are remembered and served by one GetPoint per ID.
+func (c *Client) GetPointsBatch(ctx context.Context, collection string, ids []uint64, options *GetPointsBatchOptions, opts ...CallOption) ([]PointResponse, error) {
+	includeValues := true
+	if options != nil && options.IncludeValues != nil {
+		includeValues = *options.IncludeValues
+	}
+	if len(ids) == 0 {
+		return []PointResponse{}, nil
+	}
+	scope, cached := c.pointCacheScope(ctx, newCallConfig(opts))
+	if !cached {
+		return c.getPointsBatch(ctx, collection, ids, includeValues, opts)
+	}
+
+	name := strings.TrimSpace(collection)
+	found := make(map[uint64]PointResponse, len(ids))
+	var missing []uint64
+	for _, id := range ids {
+		if point, hit := c.pointCache.get(scope, name, id); hit {
+			found[id] = point
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		fetched, err := c.getPointsBatch(ctx, collection, missing, includeValues, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, point := range fetched {
+			found[point.ID] = point
+			if includeValues {
+				c.pointCache.put(scope, name, point)
+			}
+		}
+	}
+	points := make([]PointResponse, 0, len(found))
+	for _, id := range ids {
+		if point, hit := found[id]; hit {
+			points = append(points, point)
+		}
+	}
+	if !includeValues {
+		dropPointValues(points)
+	}
+	return points, nil
+}
+
+func (c *Client) getPointsBatch(ctx context.Context, collection string, ids []uint64, includeValues bool, opts []CallOption) ([]PointResponse, error) {
+	if !c.capabilities.supported(capabilityPointsBatchGet) {
+		return c.getPointsOneByOne(ctx, collection, ids, includeValues, opts)
+	}
+
+	path := fmt.Sprintf("/collections/%s/points/get", collectionPathSegment(collection))
+	body := map[string]any{"ids": ids, "include_values": includeValues}
+	var response getPointsBatchResponse
+	err := c.requestJSON(ctx, http.MethodPost, path, body, &response, opts...)
+	if isStatus(err, http.StatusNotFound) || isStatus(err, http.StatusMethodNotAllowed) {
+		if exists, collectionErr := c.collectionExists(ctx, collection, opts); collectionErr != nil || !exists {
+			return nil, err
+		}
+		c.capabilities.markUnsupported(capabilityPointsBatchGet)
+		return c.getPointsOneByOne(ctx, collection, ids, includeValues, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.capabilities.markSupported(capabilityPointsBatchGet)
+	if !includeValues {
+		dropPointValues(response.Points)
+	}
+	return response.Points, nil
+}
+
+func (c *Client) getPointsOneByOne(ctx context.Context, collection string, ids []uint64, includeValues bool, opts []CallOption) ([]PointResponse, error) {
+	points := make([]PointResponse, 0, len(ids))
+	for _, id := range ids {
+		point, err := c.GetPoint(ctx, collection, id, opts...)
+		if isStatus(err, http.StatusNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	if !includeValues {
+		dropPointValues(points)
+	}
+	return points, nil
+}
+
+func (c *Client) collectionExists(ctx context.Context, collection string, opts []CallOption) (bool, error) {
+	_, err := c.GetCollection(ctx, collection, opts...)
+	if isStatus(err, http.StatusNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func dropPointValues(points []PointResponse) {
+	for index := range points {
+		points[index].Values = nil
+	}
+}
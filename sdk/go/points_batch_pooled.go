@@ -0,0 +1,154 @@
+package aionbd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// PointBatch is the result of GetPointsBatchPooled. The vectors of its points
+// share one pooled backing array.
+type PointBatch struct {
+	Points []PointResponse
+	arena  *[]float32
+}
+
+// Release returns the batch's vector storage to the pool. Points and every
+// Values slice taken from them are invalid afterwards; copy vectors that must
+// outlive the batch. Release is idempotent.
+func (b *PointBatch) Release() {
+	if b.arena != nil {
+		*b.arena = (*b.arena)[:0]
+		vectorArenaPool.Put(b.arena)
+		b.arena = nil
+	}
+	b.Points = nil
+}
+
+var vectorArenaPool = sync.Pool{New: func() any { return new([]float32) }}
+
+// rawVector keeps the undecoded JSON array of a point's values. encoding/json
+// passes a sub-slice of the response body, so nothing is copied.
+type rawVector []byte
+
+func (v *rawVector) UnmarshalJSON(data []byte) error {
+	*v = data
+	return nil
+}
+
+type rawVectorPoint struct {
+	ID      uint64       `json:"id"`
+	Values  rawVector    `json:"values"`
+	Payload PointPayload `json:"payload"`
+}
+
+type rawVectorPointsResponse struct {
+	Points []rawVectorPoint `json:"points"`
+}
+
+// GetPointsBatchPooled is GetPointsBatch for large reads: all vectors are
+// decoded into one contiguous array borrowed from a pool instead of one
+// allocation per point. Call Release when done with the batch. It always asks
+// the server, neither reading nor filling PointCache; on servers without the
+// batch endpoint it falls back to GetPoint per ID without pooling.
+func (c *Client) GetPointsBatchPooled(ctx context.Context, collection string, ids []uint64, options *GetPointsBatchOptions, opts ...CallOption) (*PointBatch, error) {
+	includeValues := true
+	if options != nil && options.IncludeValues != nil {
+		includeValues = *options.IncludeValues
+	}
+	if len(ids) == 0 {
+		return &PointBatch{Points: []PointResponse{}}, nil
+	}
+	if !includeValues || !c.capabilities.supported(capabilityPointsBatchGet) {
+		points, err := c.getPointsBatch(ctx, collection, ids, includeValues, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &PointBatch{Points: points}, nil
+	}
+
+	path := fmt.Sprintf("/collections/%s/points/get", collectionPathSegment(collection))
+	body := map[string]any{"ids": ids, "include_values": true}
+	var response rawVectorPointsResponse
+	err := c.requestJSON(ctx, http.MethodPost, path, body, &response, opts...)
+	if isStatus(err, http.StatusNotFound) || isStatus(err, http.StatusMethodNotAllowed) {
+		if exists, collectionErr := c.collectionExists(ctx, collection, opts); collectionErr != nil || !exists {
+			return nil, err
+		}
+		c.capabilities.markUnsupported(capabilityPointsBatchGet)
+		points, err := c.getPointsOneByOne(ctx, collection, ids, true, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &PointBatch{Points: points}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.capabilities.markSupported(capabilityPointsBatchGet)
+	return decodePooledPoints(response.Points, path)
+}
+
+func decodePooledPoints(raw []rawVectorPoint, path string) (*PointBatch, error) {
+	total := 0
+	for _, point := range raw {
+		total += countJSONArrayItems(point.Values)
+	}
+	arena := vectorArenaPool.Get().(*[]float32)
+	if cap(*arena) < total {
+		*arena = make([]float32, 0, total)
+	}
+	batch := &PointBatch{Points: make([]PointResponse, len(raw)), arena: arena}
+	for index, point := range raw {
+		start := len(*arena)
+		values, err := appendJSONFloats(*arena, point.Values)
+		if err != nil {
+			batch.Release()
+			return nil, &Error{Method: http.MethodPost, Path: path, Err: fmt.Errorf("invalid JSON response: point %d: %w", point.ID, err)}
+		}
+		*arena = values
+		batch.Points[index] = PointResponse{ID: point.ID, Payload: point.Payload}
+		if len(point.Values) > 0 && !bytes.Equal(point.Values, []byte("null")) {
+			batch.Points[index].Values = values[start:len(values):len(values)]
+		}
+	}
+	return batch, nil
+}
+
+// countJSONArrayItems counts the elements of a JSON array of numbers by its
+// separators; it only sizes the arena, so it may overcount.
+func countJSONArrayItems(data []byte) int {
+	if len(bytes.Trim(data, " \t\r\n[]")) == 0 {
+		return 0
+	}
+	return bytes.Count(data, []byte(",")) + 1
+}
+
+// appendJSONFloats parses a JSON array of numbers (already validated by
+// encoding/json) into dst the way encoding/json decodes float32 values.
+func appendJSONFloats(dst []float32, data []byte) ([]float32, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return dst, nil
+	}
+	if data[0] != '[' || data[len(data)-1] != ']' {
+		return dst, fmt.Errorf("values is not an array")
+	}
+	data = data[1 : len(data)-1]
+	if len(bytes.TrimSpace(data)) == 0 {
+		return dst, nil
+	}
+	for len(data) > 0 {
+		item, rest, _ := bytes.Cut(data, []byte(","))
+		data = rest
+		value, err := strconv.ParseFloat(string(bytes.TrimSpace(item)), 32)
+		if err != nil {
+			return dst, fmt.Errorf("values: %w", err)
+		}
+		dst = append(dst, float32(value))
+	}
+	return dst, nil
+}
@@ -0,0 +1,100 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestGetPointsBatchPooledMatchesGetPointsBatch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(`{"points":[
+			{"id":1,"values":[0.1, -2.5e-3 ,3],"payload":{"tag":"a"}},
+			{"id":2,"values":[],"payload":{}},
+			{"id":3,"values":[1.0000001,16777217,-0]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ids := []uint64{1, 2, 3}
+	want, err := client.GetPointsBatch(context.Background(), "demo", ids, nil)
+	if err != nil {
+		t.Fatalf("GetPointsBatch failed: %v", err)
+	}
+	batch, err := client.GetPointsBatchPooled(context.Background(), "demo", ids, nil)
+	if err != nil {
+		t.Fatalf("GetPointsBatchPooled failed: %v", err)
+	}
+	defer batch.Release()
+
+	if len(batch.Points) != len(want) {
+		t.Fatalf("expected %d points, got %d", len(want), len(batch.Points))
+	}
+	for index := range want {
+		got := batch.Points[index]
+		if got.ID != want[index].ID || !slices.Equal(got.Values, want[index].Values) || got.Payload["tag"] != want[index].Payload["tag"] {
+			t.Fatalf("point %d: expected %+v, got %+v", index, want[index], got)
+		}
+	}
+	arena := *batch.arena
+	first, third := batch.Points[0].Values, batch.Points[2].Values
+	if &arena[0] != &first[0] || &arena[len(first)] != &third[0] {
+		t.Fatal("expected vectors to share one contiguous backing array")
+	}
+	if cap(first) != len(first) {
+		t.Fatal("expected appends to a vector not to overwrite its neighbour")
+	}
+}
+
+func BenchmarkGetPointsBatch(b *testing.B) {
+	const points, dimension = 512, 128
+	response := struct {
+		Points []PointResponse `json:"points"`
+	}{Points: make([]PointResponse, points)}
+	ids := make([]uint64, points)
+	for index := range response.Points {
+		values := make([]float32, dimension)
+		for position := range values {
+			values[position] = float32(index*dimension+position) / 1000
+		}
+		response.Points[index] = PointResponse{ID: uint64(index), Values: values}
+		ids[index] = uint64(index)
+	}
+	body, err := json.Marshal(response)
+	if err != nil {
+		b.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write(body)
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, nil)
+	ctx := context.Background()
+
+	b.Run("GetPointsBatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := client.GetPointsBatch(ctx, "demo", ids, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("GetPointsBatchPooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			batch, err := client.GetPointsBatchPooled(ctx, "demo", ids, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			batch.Release()
+		}
+	})
+}
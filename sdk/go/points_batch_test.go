@@ -0,0 +1,82 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPointsBatchHonorsIncludeValues(t *testing.T) {
+	t.Parallel()
+
+	var flags []any
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost || request.URL.Path != "/collections/demo/points/get" {
+			t.Fatalf("unexpected request: %s %s", request.Method, request.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		flags = append(flags, body["include_values"])
+		point := map[string]any{"id": 1, "payload": map[string]any{"tag": "a"}}
+		if body["include_values"] == true {
+			point["values"] = []float32{0.5, 0.25}
+		}
+		writeJSON(t, writer, map[string]any{"points": []map[string]any{point}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	withValues, err := client.GetPointsBatch(context.Background(), "demo", []uint64{1}, nil)
+	if err != nil {
+		t.Fatalf("batch get with values failed: %v", err)
+	}
+	if len(withValues) != 1 || len(withValues[0].Values) != 2 {
+		t.Fatalf("expected values by default: %#v", withValues)
+	}
+
+	withoutValues, err := client.GetPointsBatch(context.Background(), "demo", []uint64{1}, &GetPointsBatchOptions{IncludeValues: BoolPtr(false)})
+	if err != nil {
+		t.Fatalf("batch get without values failed: %v", err)
+	}
+	if len(withoutValues) != 1 || withoutValues[0].Values != nil || withoutValues[0].Payload["tag"] != "a" {
+		t.Fatalf("expected nil values with payload kept: %#v", withoutValues)
+	}
+	if len(flags) != 2 || flags[0] != true || flags[1] != false {
+		t.Fatalf("unexpected include_values flags: %#v", flags)
+	}
+}
+
+func TestGetPointsBatchFallsBackToSingleGets(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch {
+		case request.Method == http.MethodPost:
+			writer.WriteHeader(http.StatusMethodNotAllowed)
+		case request.URL.Path == "/collections/demo":
+			writeJSON(t, writer, map[string]any{"name": "demo", "dimension": 2, "strict_finite": true, "point_count": 1})
+		case request.URL.Path == "/collections/demo/points/1":
+			writeJSON(t, writer, map[string]any{"id": 1, "values": []float32{1, 0}, "payload": map[string]any{}})
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+			writeJSON(t, writer, map[string]any{"error": "point not found"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	points, err := client.GetPointsBatch(context.Background(), "demo", []uint64{1, 2}, &GetPointsBatchOptions{IncludeValues: BoolPtr(false)})
+	if err != nil {
+		t.Fatalf("fallback batch get failed: %v", err)
+	}
+	if len(points) != 1 || points[0].ID != 1 || points[0].Values != nil {
+		t.Fatalf("unexpected fallback points: %#v", points)
+	}
+	if client.capabilities.supported(capabilityPointsBatchGet) {
+		t.Fatal("expected missing batch endpoint to be remembered")
+	}
+}
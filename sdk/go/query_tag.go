@@ -0,0 +1,48 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const queryTagHeader = "X-Query-Tag"
+
+type queryTagKey struct{}
+
+// WithQueryTag returns a context whose search requests carry tag in an
+// X-Query-Tag header, so the server can log it next to each query. Other
+// requests made with the context, such as health checks, metrics, and
+// writes, do not send it. Searches answered from the SearchCache never reach
+// the server and are not logged there.
+func WithQueryTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, queryTagKey{}, tag)
+}
+
+func queryTag(ctx context.Context) string {
+	tag, _ := ctx.Value(queryTagKey{}).(string)
+	return tag
+}
+
+// searchPathSuffixes are the segments following /collections/{name} in the
+// collection search endpoints.
+var searchPathSuffixes = [][]string{{"search"}, {"search", "topk"}, {"search", "topk", "batch"}}
+
+// isSearchRequest reports whether method and the escaped path address one of
+// the collection search endpoints, allowing for a base URL path prefix. The
+// path is matched segment by segment, so requests about a collection that is
+// itself named "search" are not mistaken for searches.
+func isSearchRequest(method string, path string) bool {
+	if method != http.MethodPost {
+		return false
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, suffix := range searchPathSuffixes {
+		start := len(segments) - len(suffix) - 2
+		if start >= 0 && segments[start] == "collections" && segments[start+1] != "" && slices.Equal(segments[start+2:], suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestWithQueryTagOnlyTagsSearchRequests(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	tags := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mu.Lock()
+		tags[request.URL.Path] = request.Header.Get("X-Query-Tag")
+		mu.Unlock()
+		switch request.URL.Path {
+		case "/live":
+			writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 1})
+		case "/metrics":
+			writeJSON(t, writer, map[string]any{"uptime_ms": 1})
+		default:
+			writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": []map[string]any{}})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ctx := WithQueryTag(context.Background(), "checkout-recs")
+	if _, err := client.SearchCollectionTopK(ctx, "demo", []float32{1, 2}, nil); err != nil {
+		t.Fatalf("SearchCollectionTopK failed: %v", err)
+	}
+	if _, err := client.Live(ctx); err != nil {
+		t.Fatalf("Live failed: %v", err)
+	}
+	if _, err := client.Metrics(ctx); err != nil {
+		t.Fatalf("Metrics failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := tags["/collections/demo/search/topk"]; got != "checkout-recs" {
+		t.Fatalf("expected the search to be tagged, got %q", got)
+	}
+	if tags["/live"] != "" || tags["/metrics"] != "" {
+		t.Fatalf("tag leaked into health or metrics calls: %v", tags)
+	}
+}
+
+func TestIsSearchRequestMatchesPathSegments(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{http.MethodPost, "/collections/demo/search", true},
+		{http.MethodPost, "/collections/demo/search/topk", true},
+		{http.MethodPost, "/api/v1/collections/demo/search/topk/batch", true},
+		{http.MethodPost, "/collections/search/search", true},
+		{http.MethodGet, "/collections/search", false},
+		{http.MethodDelete, "/collections/search", false},
+		{http.MethodPost, "/collections/search/points", false},
+		{http.MethodGet, "/collections/demo/search", false},
+		{http.MethodPost, "/search", false},
+	}
+	for _, tc := range cases {
+		if got := isSearchRequest(tc.method, tc.path); got != tc.want {
+			t.Errorf("isSearchRequest(%s, %s) = %v, want %v", tc.method, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestWithQueryTagKeepsCoalescedSearchesApart(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var tags []string
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mu.Lock()
+		tags = append(tags, request.Header.Get("X-Query-Tag"))
+		mu.Unlock()
+		<-release
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": []map[string]any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{CoalesceSearches: true})
+	var finished sync.WaitGroup
+	for _, tag := range []string{"checkout-recs", "home-feed"} {
+		finished.Add(1)
+		go func() {
+			defer finished.Done()
+			if _, err := client.SearchCollectionTopK(WithQueryTag(context.Background(), tag), "demo", []float32{1, 2}, nil); err != nil {
+				t.Errorf("SearchCollectionTopK(%s) failed: %v", tag, err)
+			}
+		}()
+	}
+	// Wait until both searches are either in flight or waiting on one.
+	for started := 0; started < 2; {
+		started, _ = client.coalescer.callers()
+		runtime.Gosched()
+	}
+	close(release)
+	finished.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(tags) != 2 || tags[0] == tags[1] {
+		t.Fatalf("expected one request per tag, got %q", tags)
+	}
+}
@@ -0,0 +1,99 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+var defaultRateLimitSkipPaths = []string{"/live", "/ready", "/metrics", "/metrics/prometheus"}
+
+// RateLimit paces outgoing requests with a token bucket refilled at
+// RequestsPerSecond and holding at most Burst tokens. Requests whose path
+// (without query string) is listed in SkipPaths are never delayed; a nil
+// SkipPaths exempts the health and metrics endpoints.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+	SkipPaths         []string
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	skip   map[string]bool
+}
+
+func newTokenBucket(limit *RateLimit) *tokenBucket {
+	if limit == nil || limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := math.Max(1, float64(limit.Burst))
+	skipPaths := limit.SkipPaths
+	if skipPaths == nil {
+		skipPaths = defaultRateLimitSkipPaths
+	}
+	skip := make(map[string]bool, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = true
+	}
+	return &tokenBucket{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+		skip:   skip,
+	}
+}
+
+// wait reserves a token, sleeping until it is due. The reservation is
+// returned when the context ends first, or up front when the context deadline
+// falls before the token would be available.
+func (bucket *tokenBucket) wait(ctx context.Context, path string) error {
+	if bucket == nil || bucket.skip[pathWithoutQuery(path)] {
+		return nil
+	}
+
+	bucket.mu.Lock()
+	now := time.Now()
+	bucket.tokens = math.Min(bucket.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*bucket.rate)
+	bucket.last = now
+	bucket.tokens--
+	deficit := -bucket.tokens
+	bucket.mu.Unlock()
+	if deficit <= 0 {
+		return nil
+	}
+
+	delay := time.Duration(deficit / bucket.rate * float64(time.Second))
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		bucket.refund()
+		return fmt.Errorf("rate limit wait of %s exceeds context deadline: %w", delay, context.DeadlineExceeded)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		bucket.refund()
+		return ctx.Err()
+	}
+}
+
+func (bucket *tokenBucket) refund() {
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+	bucket.tokens = math.Min(bucket.burst, bucket.tokens+1)
+}
+
+func pathWithoutQuery(path string) string {
+	trimmed, _, _ := strings.Cut(path, "?")
+	return trimmed
+}
@@ -0,0 +1,104 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Servers and gateways that enforce a quota report it through
+// X-RateLimit-Remaining and X-RateLimit-Reset. The client records the latest
+// values from every response. Requests issued by SearchMultiCollectionTopK and
+// the batch helpers then reserve from that budget before they are sent: once
+// the budget is taken up by requests still in flight, they pause until the
+// reset instead of running into 429 responses. When the reset time passes, the
+// budget is unknown again until the next response reports it.
+
+const unixResetThreshold = 1_000_000_000
+
+type serverThrottleKey struct{}
+
+type serverThrottle struct {
+	mu        sync.Mutex
+	known     bool
+	remaining int
+	resetAt   time.Time
+	inFlight  int
+}
+
+func withServerThrottle(ctx context.Context) context.Context {
+	return context.WithValue(ctx, serverThrottleKey{}, true)
+}
+
+func serverThrottled(ctx context.Context) bool {
+	throttled, _ := ctx.Value(serverThrottleKey{}).(bool)
+	return throttled
+}
+
+// observe records the quota reported by a response. Responses can arrive out
+// of order, so within the same window the lowest reported budget wins.
+func (throttle *serverThrottle) observe(header http.Header, now time.Time) {
+	remaining, err := strconv.Atoi(strings.TrimSpace(header.Get("X-RateLimit-Remaining")))
+	if err != nil {
+		return
+	}
+	resetAt, ok := parseRateLimitReset(header.Get("X-RateLimit-Reset"), now)
+	if !ok {
+		return
+	}
+
+	throttle.mu.Lock()
+	defer throttle.mu.Unlock()
+	sameWindow := throttle.known && now.Before(throttle.resetAt)
+	if sameWindow && throttle.remaining < remaining {
+		remaining = throttle.remaining
+	}
+	throttle.known = true
+	throttle.remaining = max(remaining, 0)
+	throttle.resetAt = resetAt
+}
+
+// reserve admits one request, sleeping until the reset while the reported
+// budget is taken up by requests already in flight. Every successful reserve
+// must be paired with release once the response has been observed.
+func (throttle *serverThrottle) reserve(ctx context.Context) error {
+	for {
+		throttle.mu.Lock()
+		now := time.Now()
+		if throttle.known && !now.Before(throttle.resetAt) {
+			throttle.known = false
+		}
+		if !throttle.known || throttle.remaining > throttle.inFlight {
+			throttle.inFlight++
+			throttle.mu.Unlock()
+			return nil
+		}
+		delay := throttle.resetAt.Sub(now)
+		throttle.mu.Unlock()
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (throttle *serverThrottle) release() {
+	throttle.mu.Lock()
+	defer throttle.mu.Unlock()
+	throttle.inFlight--
+}
+
+// parseRateLimitReset accepts either seconds until the reset, possibly
+// fractional, or a unix timestamp in seconds.
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+	if seconds >= unixResetThreshold {
+		return time.Unix(0, int64(seconds*float64(time.Second))), true
+	}
+	return now.Add(time.Duration(seconds * float64(time.Second))), true
+}
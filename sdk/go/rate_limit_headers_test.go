@@ -0,0 +1,96 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSearchMultiCollectionTopKPausesBeforeQuotaIsExhausted(t *testing.T) {
+	t.Parallel()
+
+	const budget = 3
+	const window = 300 * time.Millisecond
+	var mu sync.Mutex
+	var windowEnd time.Time
+	var used, windows, rejected int
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mu.Lock()
+		now := time.Now()
+		if !now.Before(windowEnd) {
+			windowEnd = now.Add(window)
+			used = 0
+			windows++
+		}
+		used++
+		remaining := budget - used
+		reset := windowEnd.Sub(now).Seconds()
+		if remaining < 0 {
+			rejected++
+		}
+		mu.Unlock()
+
+		writer.Header().Set("X-RateLimit-Remaining", strconv.Itoa(max(remaining, 0)))
+		writer.Header().Set("X-RateLimit-Reset", strconv.FormatFloat(reset, 'f', 3, 64))
+		if remaining < 0 {
+			writer.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": []map[string]any{{"id": 1, "value": 0.5}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	collections := make([]string, 7)
+	for index := range collections {
+		collections[index] = fmt.Sprintf("shard-%d", index)
+	}
+	response, err := client.SearchMultiCollectionTopK(context.Background(), collections, []float32{1, 2}, &SearchMultiOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("SearchMultiCollectionTopK failed: %v", err)
+	}
+	results := response.Results
+	if len(results) != len(collections) {
+		t.Fatalf("expected %d results, got %d", len(collections), len(results))
+	}
+	if results["shard-4"].Hits[0].ID != 1 {
+		t.Fatalf("unexpected result: %+v", results["shard-4"])
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if rejected != 0 {
+		t.Fatalf("expected the client to pause before exhausting the quota, got %d rejected requests", rejected)
+	}
+	if windows < 3 {
+		t.Fatalf("expected requests to spread over at least 3 quota windows, got %d", windows)
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	cases := []struct {
+		value string
+		want  time.Time
+		ok    bool
+	}{
+		{"30", now.Add(30 * time.Second), true},
+		{"0.25", now.Add(250 * time.Millisecond), true},
+		{"1700000060", time.Unix(1_700_000_060, 0), true},
+		{"-1", time.Time{}, false},
+		{"soon", time.Time{}, false},
+	}
+	for _, tc := range cases {
+		got, ok := parseRateLimitReset(tc.value, now)
+		if ok != tc.ok || !got.Equal(tc.want) {
+			t.Fatalf("parseRateLimitReset(%q) = %v, %v; want %v, %v", tc.value, got, ok, tc.want, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newRateLimitServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writeJSON(t, writer, map[string]any{"name": "demo", "dimension": 2})
+	}))
+}
+
+func TestRateLimitPacesRequests(t *testing.T) {
+	t.Parallel()
+
+	server := newRateLimitServer(t)
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{RateLimit: &RateLimit{RequestsPerSecond: 20, Burst: 1}})
+	started := time.Now()
+	for index := 0; index < 5; index++ {
+		if _, err := client.GetCollection(context.Background(), "demo"); err != nil {
+			t.Fatalf("get collection failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(started); elapsed < 180*time.Millisecond {
+		t.Fatalf("expected 5 requests at 20/s to take ~200ms, took %s", elapsed)
+	}
+
+	started = time.Now()
+	for index := 0; index < 5; index++ {
+		if _, err := client.Live(context.Background()); err != nil {
+			t.Fatalf("live failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(started); elapsed > 100*time.Millisecond {
+		t.Fatalf("expected /live to skip the limiter, took %s", elapsed)
+	}
+}
+
+func TestRateLimitWaitHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	server := newRateLimitServer(t)
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{RateLimit: &RateLimit{RequestsPerSecond: 1, Burst: 1}})
+	if _, err := client.GetCollection(context.Background(), "demo"); err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	started := time.Now()
+	_, err := client.GetCollection(ctx, "demo")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation while waiting, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Fatalf("cancellation did not interrupt the wait: %s", elapsed)
+	}
+}
@@ -0,0 +1,23 @@
+package aionbd
+
+import "fmt"
+
+// Problems lists human-readable reasons the server is not ready, one per
+// failed check. It is empty for a fully ready response.
+func (r ReadyResponse) Problems() []string {
+	var problems []string
+	if !r.Checks.EngineLoaded {
+		problems = append(problems, "engine not loaded")
+	}
+	if !r.Checks.StorageAvailable {
+		problems = append(problems, "storage unavailable")
+	}
+	if len(problems) == 0 && r.Status != "" && r.Status != "ready" {
+		problems = append(problems, fmt.Sprintf("status is %q", r.Status))
+	}
+	return problems
+}
+
+func (r ReadyResponse) IsHealthy() bool {
+	return len(r.Problems()) == 0
+}
@@ -0,0 +1,29 @@
+package aionbd
+
+import "testing"
+
+func TestReadyResponseProblemsForPartialReadiness(t *testing.T) {
+	t.Parallel()
+
+	partial := ReadyResponse{
+		Status: "not_ready",
+		Checks: ReadyChecks{EngineLoaded: true, StorageAvailable: false},
+	}
+	problems := partial.Problems()
+	if len(problems) != 1 || problems[0] != "storage unavailable" {
+		t.Fatalf("unexpected problems: %#v", problems)
+	}
+	if partial.IsHealthy() {
+		t.Fatal("expected partially ready response to be unhealthy")
+	}
+
+	down := ReadyResponse{Status: "not_ready"}
+	if got := down.Problems(); len(got) != 2 || got[0] != "engine not loaded" {
+		t.Fatalf("unexpected problems for down server: %#v", got)
+	}
+
+	ready := ReadyResponse{Status: "ready", Checks: ReadyChecks{EngineLoaded: true, StorageAvailable: true}}
+	if !ready.IsHealthy() || len(ready.Problems()) != 0 {
+		t.Fatalf("expected ready response to be healthy, got: %#v", ready.Problems())
+	}
+}
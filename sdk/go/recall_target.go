@@ -0,0 +1,55 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// maxOverfetchRecall caps the target recall SuggestOverfetch plans for, since
+// the factor diverges at 1.0; such targets need an exact search anyway.
+const maxOverfetchRecall = 0.999
+
+// SuggestOverfetch returns how many candidates to request from an approximate
+// search so that the best k are likely to be among them. The heuristic
+// over-fetches by 1 + log2(1/(1-targetRecall)): about 2x at 0.5, 4.3x at 0.9,
+// 7.6x at 0.99, and at most 11x (targetRecall is capped at 0.999). Targets at
+// or below zero return k.
+func SuggestOverfetch(k int, targetRecall float32) int {
+	if k <= 0 {
+		return 0
+	}
+	recall := min(float64(targetRecall), maxOverfetchRecall)
+	if recall <= 0 {
+		return k
+	}
+	factor := 1 + math.Log2(1/(1-recall))
+	return int(math.Ceil(float64(k) * factor))
+}
+
+// SearchWithRecallTarget searches with target_recall set and the limit widened
+// by SuggestOverfetch, then trims the hits back to the best k. Mode defaults
+// to SearchModeIVF, since exact searches gain nothing from over-fetching.
+func (c *Client) SearchWithRecallTarget(ctx context.Context, collection string, query []float32, k int, targetRecall float32, options *SearchTopKOptions, opts ...CallOption) (SearchTopKResponse, error) {
+	if k <= 0 {
+		return SearchTopKResponse{}, fmt.Errorf("k must be a positive integer")
+	}
+	if targetRecall <= 0 || targetRecall > 1 {
+		return SearchTopKResponse{}, fmt.Errorf("target recall must be in (0, 1], got %v", targetRecall)
+	}
+	widened := SearchTopKOptions{}
+	if options != nil {
+		widened = *options
+	}
+	if widened.Mode == "" {
+		widened.Mode = SearchModeIVF
+	}
+	widened.TargetRecall = Float32Ptr(targetRecall)
+	widened.Limit = IntPtr(SuggestOverfetch(k, targetRecall))
+	response, err := c.SearchCollectionTopK(ctx, collection, query, &widened, opts...)
+	if err != nil {
+		return response, err
+	}
+	response.Hits = trimHits(response.Hits, k)
+	return response, nil
+}
@@ -0,0 +1,57 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSuggestOverfetchGrowsWithTargetRecall(t *testing.T) {
+	t.Parallel()
+
+	previous := SuggestOverfetch(10, 0)
+	if previous != 10 {
+		t.Fatalf("expected no over-fetch without a recall target, got %d", previous)
+	}
+	for _, recall := range []float32{0.5, 0.8, 0.9, 0.95, 0.99, 0.999} {
+		suggested := SuggestOverfetch(10, recall)
+		if suggested <= previous {
+			t.Fatalf("expected SuggestOverfetch(10, %v) = %d to exceed %d", recall, suggested, previous)
+		}
+		previous = suggested
+	}
+	if capped := SuggestOverfetch(10, 1); capped != previous {
+		t.Fatalf("expected recall 1.0 to be capped like 0.999, got %d and %d", capped, previous)
+	}
+}
+
+func TestSearchWithRecallTargetOverfetchesAndTrims(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		hits := make([]map[string]any, int(body["limit"].(float64)))
+		for index := range hits {
+			hits[index] = map[string]any{"id": index, "value": 1 - float64(index)/100}
+		}
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "ivf", "hits": hits})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	response, err := client.SearchWithRecallTarget(context.Background(), "demo", []float32{1, 2}, 5, 0.9, nil)
+	if err != nil {
+		t.Fatalf("SearchWithRecallTarget failed: %v", err)
+	}
+	if body["limit"] != float64(SuggestOverfetch(5, 0.9)) || body["mode"] != "ivf" || body["target_recall"] == nil {
+		t.Fatalf("unexpected request body: %v", body)
+	}
+	if len(response.Hits) != 5 || response.Hits[0].ID != 0 {
+		t.Fatalf("expected the best 5 hits, got %+v", response.Hits)
+	}
+}
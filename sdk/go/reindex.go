@@ -0,0 +1,148 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultReindexBatchSize   = 256
+	defaultReindexConcurrency = 4
+)
+
+type ReindexStep string
+
+const (
+	ReindexCreate       ReindexStep = "create"
+	ReindexCopy         ReindexStep = "copy"
+	ReindexWarmup       ReindexStep = "warmup"
+	ReindexSwapAlias    ReindexStep = "swap_alias"
+	ReindexDeleteSource ReindexStep = "delete_source"
+	ReindexRollback     ReindexStep = "rollback"
+)
+
+// ReindexConfig describes a Reindex run. Dimension defaults to the source's,
+// BatchSize to 256, Concurrency to 4, and WarmupQueries to 8. Alias is only
+// swapped when set, and the source is only deleted with DeleteSource.
+type ReindexConfig struct {
+	Source        string
+	Target        string
+	Alias         string
+	Dimension     int
+	BatchSize     int
+	Concurrency   int
+	WarmupQueries int
+	DeleteSource  bool
+	// OnStep is called before each step starts, including a rollback.
+	OnStep func(step ReindexStep)
+}
+
+type ReindexResult struct {
+	Created       bool
+	Copy          CopyStats
+	Warmed        int
+	AliasSwapped  bool
+	SourceDeleted bool
+	// Resumed is set when the source was already gone, so an earlier run had
+	// copied the points and only the remaining steps were run.
+	Resumed    bool
+	RolledBack bool
+}
+
+// Reindex copies Source into a new Target collection and switches traffic to
+// it: EnsureCollection, CopyPoints, WarmupCollection, SetCollectionAlias, and
+// finally DeleteCollection on the source. Every step is safe to repeat, so
+// running the same config again resumes an interrupted run: an existing
+// target of the right dimension is reused, copies overwrite identical points,
+// and a missing source with an existing target skips straight to the alias.
+// When a step before the alias swap fails, a target created by this run is
+// deleted again; once the alias points at the target it is never rolled back.
+func (c *Client) Reindex(ctx context.Context, cfg ReindexConfig) (ReindexResult, error) {
+	source, target := strings.TrimSpace(cfg.Source), strings.TrimSpace(cfg.Target)
+	if source == "" || target == "" {
+		return ReindexResult{}, fmt.Errorf("reindex source and target must not be empty")
+	}
+	if source == target {
+		return ReindexResult{}, fmt.Errorf("reindex source and target must differ")
+	}
+	step := func(name ReindexStep) {
+		if cfg.OnStep != nil {
+			cfg.OnStep(name)
+		}
+	}
+	var result ReindexResult
+	fail := func(name ReindexStep, err error) (ReindexResult, error) {
+		err = fmt.Errorf("reindex %s: %w", name, err)
+		if !result.Created || result.AliasSwapped {
+			return result, err
+		}
+		step(ReindexRollback)
+		if _, rollbackErr := c.DeleteCollection(context.WithoutCancel(ctx), target); rollbackErr != nil && !isStatus(rollbackErr, http.StatusNotFound) {
+			return result, errors.Join(err, fmt.Errorf("reindex rollback: %w", rollbackErr))
+		}
+		result.RolledBack = true
+		return result, err
+	}
+
+	sourceInfo, err := c.GetCollection(ctx, source)
+	if isStatus(err, http.StatusNotFound) {
+		if _, targetErr := c.GetCollection(ctx, target); targetErr != nil {
+			return result, fmt.Errorf("reindex: source %q not found: %w", source, err)
+		}
+		result.Resumed = true
+	} else if err != nil {
+		return result, fmt.Errorf("reindex: %w", err)
+	}
+
+	if !result.Resumed {
+		dimension := cfg.Dimension
+		if dimension <= 0 {
+			dimension = sourceInfo.Dimension
+		}
+		step(ReindexCreate)
+		_, created, err := c.EnsureCollection(ctx, target, dimension, sourceInfo.StrictFinite)
+		result.Created = created
+		if err != nil {
+			return fail(ReindexCreate, err)
+		}
+
+		step(ReindexCopy)
+		result.Copy, err = c.CopyPoints(ctx, source, target, positiveOr(cfg.BatchSize, defaultReindexBatchSize), positiveOr(cfg.Concurrency, defaultReindexConcurrency))
+		if err != nil {
+			return fail(ReindexCopy, err)
+		}
+	}
+
+	step(ReindexWarmup)
+	result.Warmed, err = c.WarmupCollection(ctx, target, cfg.WarmupQueries)
+	if err != nil {
+		return fail(ReindexWarmup, err)
+	}
+
+	if cfg.Alias != "" {
+		step(ReindexSwapAlias)
+		if err := c.SetCollectionAlias(ctx, cfg.Alias, target); err != nil {
+			return fail(ReindexSwapAlias, err)
+		}
+		result.AliasSwapped = true
+	}
+
+	if cfg.DeleteSource && !result.Resumed {
+		step(ReindexDeleteSource)
+		if _, err := c.DeleteCollection(ctx, source); err != nil && !isStatus(err, http.StatusNotFound) {
+			return fail(ReindexDeleteSource, err)
+		}
+		result.SourceDeleted = true
+	}
+	return result, nil
+}
+
+func positiveOr(value, fallback int) int {
+	if value > 0 {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,220 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// reindexServer is an in-memory stand-in for the endpoints Reindex touches.
+type reindexServer struct {
+	t           *testing.T
+	mu          sync.Mutex
+	collections map[string]map[uint64]PointResponse
+	dimensions  map[string]int
+	aliases     map[string]string
+	failAlias   bool
+	deleted     []string
+}
+
+func newReindexServer(t *testing.T) *reindexServer {
+	points := map[uint64]PointResponse{}
+	for id := uint64(1); id <= 5; id++ {
+		points[id] = PointResponse{ID: id, Values: []float32{float32(id), 1}, Payload: PointPayload{"n": float64(id)}}
+	}
+	return &reindexServer{
+		t:           t,
+		collections: map[string]map[uint64]PointResponse{"v1": points},
+		dimensions:  map[string]int{"v1": 2},
+		aliases:     map[string]string{},
+	}
+}
+
+func (s *reindexServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	parts := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+	if parts[0] == "aliases" && request.Method == http.MethodPut {
+		if s.failAlias {
+			http.Error(writer, "alias store unavailable", http.StatusInternalServerError)
+			return
+		}
+		var body struct {
+			Collection string `json:"collection"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			s.t.Errorf("decode alias body: %v", err)
+		}
+		s.aliases[parts[1]] = body.Collection
+		writeJSON(s.t, writer, map[string]any{"alias": parts[1], "collection": body.Collection})
+		return
+	}
+	if parts[0] != "collections" {
+		http.NotFound(writer, request)
+		return
+	}
+	if len(parts) == 1 && request.Method == http.MethodPost {
+		var body struct {
+			Name      string `json:"name"`
+			Dimension int    `json:"dimension"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			s.t.Errorf("decode create body: %v", err)
+		}
+		s.collections[body.Name] = map[uint64]PointResponse{}
+		s.dimensions[body.Name] = body.Dimension
+		writeJSON(s.t, writer, map[string]any{"name": body.Name, "dimension": body.Dimension, "point_count": 0})
+		return
+	}
+	name := parts[1]
+	points, ok := s.collections[name]
+	if !ok {
+		http.NotFound(writer, request)
+		return
+	}
+	switch {
+	case len(parts) == 2 && request.Method == http.MethodGet:
+		writeJSON(s.t, writer, map[string]any{"name": name, "dimension": s.dimensions[name], "point_count": len(points)})
+	case len(parts) == 2 && request.Method == http.MethodDelete:
+		delete(s.collections, name)
+		s.deleted = append(s.deleted, name)
+		writeJSON(s.t, writer, map[string]any{"name": name, "deleted": true})
+	case len(parts) == 3 && request.Method == http.MethodGet:
+		limit, err := strconv.Atoi(request.URL.Query().Get("limit"))
+		if err != nil {
+			limit = len(points)
+		}
+		after, _ := strconv.ParseUint(request.URL.Query().Get("after_id"), 10, 64)
+		page := map[string]any{"total": len(points)}
+		ids := make([]map[string]any, 0, limit)
+		for id := after + 1; id <= uint64(len(points)); id++ {
+			if len(ids) == limit {
+				page["next_after_id"] = id - 1
+				break
+			}
+			ids = append(ids, map[string]any{"id": id})
+		}
+		page["points"] = ids
+		writeJSON(s.t, writer, page)
+	case len(parts) == 3 && request.Method == http.MethodPost:
+		var body struct {
+			Points []UpsertPointsBatchItem `json:"points"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			s.t.Errorf("decode upsert body: %v", err)
+		}
+		for _, point := range body.Points {
+			points[point.ID] = PointResponse{ID: point.ID, Values: point.Values, Payload: point.Payload}
+		}
+		writeJSON(s.t, writer, map[string]any{"created": len(body.Points), "updated": 0, "results": []any{}})
+	case len(parts) == 4 && parts[3] == "get":
+		var body struct {
+			IDs []uint64 `json:"ids"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			s.t.Errorf("decode get body: %v", err)
+		}
+		found := make([]PointResponse, 0, len(body.IDs))
+		for _, id := range body.IDs {
+			found = append(found, points[id])
+		}
+		writeJSON(s.t, writer, map[string]any{"points": found})
+	case len(parts) == 4 && parts[2] == "points":
+		id, _ := strconv.ParseUint(parts[3], 10, 64)
+		point := points[id]
+		writeJSON(s.t, writer, map[string]any{"id": point.ID, "values": point.Values, "payload": point.Payload})
+	case len(parts) == 4 && parts[2] == "search":
+		writeJSON(s.t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": []map[string]any{{"id": 1, "value": 1}}})
+	default:
+		s.t.Errorf("unexpected request %s %s", request.Method, request.URL.Path)
+		http.NotFound(writer, request)
+	}
+}
+
+func TestReindexRunsStepsInOrder(t *testing.T) {
+	t.Parallel()
+
+	mock := newReindexServer(t)
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	var steps []ReindexStep
+	result, err := NewClient(server.URL, nil).Reindex(context.Background(), ReindexConfig{
+		Source:        "v1",
+		Target:        "v2",
+		Alias:         "live",
+		BatchSize:     2,
+		WarmupQueries: 3,
+		DeleteSource:  true,
+		OnStep:        func(step ReindexStep) { steps = append(steps, step) },
+	})
+	if err != nil {
+		t.Fatalf("Reindex returned error: %v", err)
+	}
+	want := []ReindexStep{ReindexCreate, ReindexCopy, ReindexWarmup, ReindexSwapAlias, ReindexDeleteSource}
+	if strings.Join(stepNames(steps), ",") != strings.Join(stepNames(want), ",") {
+		t.Fatalf("unexpected steps %v, want %v", steps, want)
+	}
+	if !result.Created || result.Copy.Copied != 5 || result.Warmed != 3 || !result.AliasSwapped || !result.SourceDeleted {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(mock.collections["v2"]) != 5 || mock.aliases["live"] != "v2" || mock.collections["v1"] != nil {
+		t.Fatalf("unexpected server state: collections=%v aliases=%v", mock.collections, mock.aliases)
+	}
+
+	// Running the same config again resumes from the alias swap.
+	steps = nil
+	result, err = NewClient(server.URL, nil).Reindex(context.Background(), ReindexConfig{
+		Source: "v1", Target: "v2", Alias: "live", DeleteSource: true,
+		OnStep: func(step ReindexStep) { steps = append(steps, step) },
+	})
+	if err != nil {
+		t.Fatalf("resumed Reindex returned error: %v", err)
+	}
+	if !result.Resumed || result.Created || len(steps) != 2 || steps[1] != ReindexSwapAlias {
+		t.Fatalf("unexpected resume: result=%+v steps=%v", result, steps)
+	}
+}
+
+func TestReindexRollsBackCreatedTargetOnFailure(t *testing.T) {
+	t.Parallel()
+
+	mock := newReindexServer(t)
+	mock.failAlias = true
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	var steps []ReindexStep
+	result, err := NewClient(server.URL, nil).Reindex(context.Background(), ReindexConfig{
+		Source:       "v1",
+		Target:       "v2",
+		Alias:        "live",
+		DeleteSource: true,
+		OnStep:       func(step ReindexStep) { steps = append(steps, step) },
+	})
+	if !isStatus(err, http.StatusInternalServerError) {
+		t.Fatalf("expected the alias failure, got %v", err)
+	}
+	if !result.RolledBack || steps[len(steps)-1] != ReindexRollback {
+		t.Fatalf("expected a rollback: result=%+v steps=%v", result, steps)
+	}
+	if len(mock.deleted) != 1 || mock.deleted[0] != "v2" {
+		t.Fatalf("expected only the target to be deleted, got %v", mock.deleted)
+	}
+	if len(mock.collections["v1"]) != 5 {
+		t.Fatalf("source must be left intact, got %v", mock.collections["v1"])
+	}
+}
+
+func stepNames(steps []ReindexStep) []string {
+	names := make([]string, len(steps))
+	for index, step := range steps {
+		names[index] = string(step)
+	}
+	return names
+}
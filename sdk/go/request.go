@@ -0,0 +1,252 @@
+package aionbd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (c *Client) requestJSON(ctx context.Context, method string, path string, body any, out any, opts ...CallOption) error {
+	_, err := c.requestJSONOrEmpty(ctx, method, path, body, out, opts...)
+	return err
+}
+
+// requestJSONOrEmpty is requestJSON that also reports whether the successful
+// response carried no body (e.g. 204 No Content), leaving out untouched.
+func (c *Client) requestJSONOrEmpty(ctx context.Context, method string, path string, body any, out any, opts ...CallOption) (bool, error) {
+	call := newCallConfig(opts)
+	payload, err := c.doRequest(ctx, method, path, body, false, call)
+	if err != nil {
+		return false, err
+	}
+	call.capture(payload)
+	if len(bytes.TrimSpace(payload)) == 0 {
+		return true, nil
+	}
+	if err := json.Unmarshal(payload, out); err != nil {
+		return false, &Error{
+			Method: method,
+			Path:   path,
+			Body:   string(payload),
+			Err:    fmt.Errorf("invalid JSON response: %w", err),
+		}
+	}
+	return false, nil
+}
+
+func (c *Client) requestRaw(ctx context.Context, method string, path string, body any, opts ...CallOption) (string, error) {
+	call := newCallConfig(opts)
+	payload, err := c.doRequest(ctx, method, path, body, true, call)
+	if err != nil {
+		return "", err
+	}
+	call.capture(payload)
+	return string(payload), nil
+}
+
+func (c *Client) encodeBody(body any) ([]byte, error) {
+	if c.canonicalJSON || c.signer != nil {
+		return canonicalJSON(body)
+	}
+	if points, ok := upsertBatchPoints(body); ok {
+		return encodeUpsertBatchBody(points)
+	}
+	return json.Marshal(body)
+}
+
+func (c *Client) doRequest(ctx context.Context, method string, path string, body any, raw bool, call *callConfig) ([]byte, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c.configErr != nil {
+		return nil, &Error{Method: method, Path: path, Err: fmt.Errorf("invalid client configuration: %w", c.configErr)}
+	}
+
+	var encoded, uncompressed []byte
+	var contentEncoding string
+	if body != nil {
+		var err error
+		encoded, err = c.encodeBody(body)
+		if err != nil {
+			return nil, &Error{Method: method, Path: path, Err: err}
+		}
+		uncompressed = encoded
+		c.bodyLog.log(ctx, method, path, encoded)
+		encoded, contentEncoding, err = c.compressBody(encoded)
+		if err != nil {
+			return nil, &Error{Method: method, Path: path, Err: err}
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		payload, err := c.sendRequestToNodes(ctx, method, path, encoded, contentEncoding, body != nil, raw, call)
+		if contentEncoding == string(CompressionZstd) && isStatus(err, http.StatusUnsupportedMediaType) {
+			c.capabilities.markUnsupported(capabilityZstdRequests)
+			encoded, contentEncoding, err = c.compressBody(uncompressed)
+			if err != nil {
+				return nil, &Error{Method: method, Path: path, Err: err}
+			}
+			payload, err = c.sendRequestToNodes(ctx, method, path, encoded, contentEncoding, true, raw, call)
+		}
+		delay, retry := c.retryDelay(ctx, attempt, method, path, call, err)
+		if !retry {
+			return payload, err
+		}
+		if c.onRetry != nil {
+			c.onRetry(attempt, newRetryInfo(method, path, err, delay))
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, &Error{Method: method, Path: path, Err: err}
+		}
+	}
+}
+
+func (c *Client) sendRequest(ctx context.Context, baseURL string, method string, path string, encoded []byte, contentEncoding string, hasBody bool, raw bool, call *callConfig) ([]byte, error) {
+	if err := c.rateLimiter.wait(ctx, path); err != nil {
+		return nil, &Error{Method: method, Path: path, Err: err}
+	}
+	if serverThrottled(ctx) {
+		if err := c.throttle.reserve(ctx); err != nil {
+			return nil, &Error{Method: method, Path: path, Err: err}
+		}
+		defer c.throttle.release()
+	}
+
+	var requestBody io.Reader
+	if hasBody {
+		requestBody = bytes.NewReader(encoded)
+	}
+
+	var trace *httpTraceRecorder
+	if c.onHTTPTrace != nil {
+		ctx, trace = withHTTPTrace(ctx, method, path)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, baseURL+path, requestBody)
+	if err != nil {
+		return nil, &Error{Method: method, Path: path, Err: err}
+	}
+	if raw {
+		request.Header.Set("Accept", "text/plain")
+	} else {
+		request.Header.Set("Accept", "application/json")
+	}
+	if acceptEncoding := c.acceptEncoding(); acceptEncoding != "" {
+		request.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	c.applyRequestHeaders(ctx, request, call)
+	if hasBody {
+		request.Header.Set("Content-Type", "application/json")
+		if contentEncoding != "" {
+			request.Header.Set("Content-Encoding", contentEncoding)
+		}
+	}
+	if c.simpleRequests {
+		c.simplifyRequest(request)
+	}
+	if c.signer != nil {
+		if err := c.signer(request, encoded); err != nil {
+			return nil, &Error{Method: method, Path: path, Err: fmt.Errorf("sign request: %w", err)}
+		}
+	}
+
+	response, err := c.httpClient.Do(request)
+	if trace != nil {
+		c.onHTTPTrace(trace.snapshot())
+	}
+	if err != nil {
+		if c.simpleRequests {
+			err = redactQueryCredentials(err)
+		}
+		return nil, &Error{Method: method, Path: path, Err: err}
+	}
+	defer response.Body.Close()
+	c.checkClockSkew(response.Header.Get("Date"), time.Now())
+	observeOperationID(ctx, response.Header)
+	c.throttle.observe(response.Header, time.Now())
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, &Error{Method: method, Path: path, Err: err}
+	}
+	responseBody, err = decodeResponseBody(response.Header.Get("Content-Encoding"), responseBody)
+	if err != nil {
+		return nil, &Error{Status: response.StatusCode, Method: method, Path: path, Err: err}
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		requestErr := &Error{
+			Status: response.StatusCode,
+			Method: method,
+			Path:   path,
+			Body:   string(responseBody),
+		}
+		if response.StatusCode == http.StatusRequestEntityTooLarge {
+			requestErr.Err = ErrPayloadTooLarge
+		}
+		return nil, requestErr
+	}
+	if response.StatusCode == http.StatusNoContent {
+		// Some proxies attach a stray body to 204 responses; it carries no data.
+		call.captureHeaders(response.Header)
+		return nil, nil
+	}
+	if !raw && !c.allowNonJSONContentType && len(bytes.TrimSpace(responseBody)) > 0 {
+		if contentType := response.Header.Get("Content-Type"); !isJSONContentType(contentType) {
+			return nil, &Error{
+				Method: method,
+				Path:   path,
+				Body:   string(responseBody),
+				Err:    fmt.Errorf("invalid JSON response: unexpected Content-Type %q", contentType),
+			}
+		}
+	}
+	call.captureHeaders(response.Header)
+	return responseBody, nil
+}
+
+// applyRequestHeaders sets the context, default, per-call, and auth headers
+// shared by every request.
+func (c *Client) applyRequestHeaders(ctx context.Context, request *http.Request, call *callConfig) {
+	for key, value := range contextHeaders(ctx) {
+		request.Header.Set(key, value)
+	}
+	if tag := queryTag(ctx); tag != "" && isSearchRequest(request.Method, request.URL.EscapedPath()) {
+		request.Header.Set(queryTagHeader, tag)
+	}
+	for key, value := range c.defaultHeader {
+		request.Header.Set(key, value)
+	}
+	for key, values := range c.multiHeader {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+	for key, values := range call.header {
+		request.Header[key] = values
+	}
+	if c.apiKey != "" {
+		request.Header.Set("x-api-key", c.apiKey)
+	}
+	if c.bearerToken != "" {
+		request.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+}
+
+// isJSONContentType accepts an empty Content-Type, application/json, and any
+// +json media type.
+func isJSONContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,175 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 2 * time.Second
+)
+
+var defaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy retries requests that failed at the transport level (connection
+// errors, timeouts, truncated responses) or with one of RetryStatuses
+// (429/502/503/504 when nil). Local failures such as signer errors are not
+// retried. Writes that are not idempotent (POST, PATCH) may already have been
+// applied when a gateway error or a broken connection is seen, so they are
+// only retried then if they carry an Idempotency-Key or RetryNonIdempotent
+// is set; a 429 or a failed dial is always retried. MaxAttempts counts the
+// first try, so values below 2 disable retries. Backoff doubles from
+// InitialBackoff up to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts        int
+	InitialBackoff     time.Duration
+	MaxBackoff         time.Duration
+	RetryStatuses      []int
+	RetryNonIdempotent bool
+}
+
+type RetryInfo struct {
+	Method       string
+	PathTemplate string
+	Status       int
+	Err          error
+	Delay        time.Duration
+}
+
+func newRetryInfo(method string, path string, err error, delay time.Duration) RetryInfo {
+	info := RetryInfo{Method: method, PathTemplate: pathTemplate(path), Err: err, Delay: delay}
+	var requestErr *Error
+	if errors.As(err, &requestErr) {
+		info.Status = requestErr.Status
+	}
+	return info
+}
+
+func (c *Client) retryDelay(ctx context.Context, attempt int, method string, path string, call *callConfig, err error) (time.Duration, bool) {
+	policy := c.retryPolicy
+	if err == nil || policy == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || errors.Is(err, ErrStaleCursor) {
+		return 0, false
+	}
+	var requestErr *Error
+	if !errors.As(err, &requestErr) {
+		return 0, false
+	}
+	switch {
+	case requestErr.Status == 0 && !isTransportError(requestErr.Err):
+		return 0, false
+	case requestErr.Status > 0 && !policy.retriesStatus(requestErr.Status):
+		return 0, false
+	case requestErr.Status == http.StatusTooManyRequests || isDialError(requestErr.Err):
+		// The server did not process the request.
+	case !policy.RetryNonIdempotent && !c.isIdempotentRequest(ctx, method, path, call):
+		return 0, false
+	}
+
+	backoff := policy.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryInitialBackoff
+	}
+	maxBackoff := policy.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+	for step := 1; step < attempt && backoff < maxBackoff; step++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff, true
+}
+
+// isTransportError reports whether err is a network failure worth retrying:
+// a failed or timed-out round trip, or a response cut short. Local failures
+// (signing, building the request, rate-limiter deadlines) are not.
+func isTransportError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Op != "parse"
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// isIdempotentRequest reports whether repeating a request cannot apply a
+// write twice: safe and idempotent methods, read-only POST endpoints
+// (searches and points/get), and requests carrying an Idempotency-Key.
+func (c *Client) isIdempotentRequest(ctx context.Context, method string, path string, call *callConfig) bool {
+	if method != http.MethodPost && method != http.MethodPatch {
+		return true
+	}
+	path = pathWithoutQuery(path)
+	if isSearchRequest(method, path) || strings.HasSuffix(path, "/points/get") {
+		return true
+	}
+	if call.header.Get(idempotencyKeyHeader) != "" || contextHeaders(ctx)[idempotencyKeyHeader] != "" {
+		return true
+	}
+	for key, value := range c.defaultHeader {
+		if http.CanonicalHeaderKey(key) == idempotencyKeyHeader && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (policy *RetryPolicy) retriesStatus(status int) bool {
+	statuses := policy.RetryStatuses
+	if statuses == nil {
+		statuses = defaultRetryStatuses
+	}
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pathTemplate replaces the collection name and point ID in an API path with
+// placeholders so retry observers can group calls by endpoint.
+func pathTemplate(path string) string {
+	segments := strings.Split(strings.TrimPrefix(pathWithoutQuery(path), "/"), "/")
+	if len(segments) >= 2 && segments[0] == "collections" {
+		segments[1] = "{collection}"
+		if len(segments) >= 4 && segments[2] == "points" {
+			if _, err := strconv.ParseUint(segments[3], 10, 64); err == nil {
+				segments[3] = "{id}"
+			}
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
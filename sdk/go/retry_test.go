@@ -0,0 +1,101 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOnRetryReportsEachAttempt(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		if requests <= 2 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(t, writer, map[string]any{"id": 7, "values": []float32{1}, "payload": map[string]any{}})
+	}))
+	defer server.Close()
+
+	var attempts []int
+	var infos []RetryInfo
+	client := NewClient(server.URL, &ClientOptions{
+		RetryPolicy: &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond},
+		OnRetry: func(attempt int, info RetryInfo) {
+			attempts = append(attempts, attempt)
+			infos = append(infos, info)
+		},
+	})
+	if _, err := client.GetPoint(context.Background(), "demo", 7); err != nil {
+		t.Fatalf("get point failed after retries: %v", err)
+	}
+
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Fatalf("unexpected retry attempts: %v", attempts)
+	}
+	for _, info := range infos {
+		if info.Method != http.MethodGet || info.PathTemplate != "/collections/{collection}/points/{id}" {
+			t.Fatalf("unexpected retry target: %#v", info)
+		}
+		if info.Status != http.StatusServiceUnavailable || info.Err == nil {
+			t.Fatalf("unexpected retry cause: %#v", info)
+		}
+	}
+	if infos[1].Delay <= infos[0].Delay {
+		t.Fatalf("expected growing backoff: %s then %s", infos[0].Delay, infos[1].Delay)
+	}
+}
+
+func TestRetryPolicyOnlyRepeatsSafeRequests(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	points := []UpsertPointsBatchItem{{ID: 1, Values: []float32{1}}}
+	cases := []struct {
+		name string
+		call func(client *Client) error
+		want int32
+	}{
+		{"search POST", func(client *Client) error {
+			_, err := client.SearchCollectionTopK(context.Background(), "demo", []float32{1}, nil)
+			return err
+		}, 3},
+		{"upsert POST", func(client *Client) error {
+			_, err := client.UpsertPointsBatch(context.Background(), "demo", points)
+			return err
+		}, 1},
+		{"upsert POST with Idempotency-Key", func(client *Client) error {
+			_, err := client.UpsertPointsBatch(context.Background(), "demo", points, WithHeader("Idempotency-Key", "k-1"))
+			return err
+		}, 3},
+		{"failing signer", func(client *Client) error {
+			signing := client.Clone()
+			signing.signer = func(*http.Request, []byte) error { return errors.New("no key") }
+			_, err := signing.GetPoint(context.Background(), "demo", 1)
+			return err
+		}, 0},
+	}
+	for _, tc := range cases {
+		requests.Store(0)
+		if err := tc.call(NewClient(server.URL, &ClientOptions{RetryPolicy: policy})); err == nil {
+			t.Fatalf("%s: expected an error", tc.name)
+		}
+		if got := requests.Load(); got != tc.want {
+			t.Fatalf("%s: expected %d requests, got %d", tc.name, tc.want, got)
+		}
+	}
+}
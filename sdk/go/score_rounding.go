@@ -0,0 +1,22 @@
+package aionbd
+
+import "math"
+
+// RoundScore rounds v to decimals digits after the decimal point. Negative
+// decimals, NaN, and infinities return v unchanged.
+func RoundScore(v float32, decimals int) float32 {
+	if decimals < 0 || math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+		return v
+	}
+	scale := math.Pow(10, float64(decimals))
+	return float32(math.Round(float64(v)*scale) / scale)
+}
+
+func roundHits(hits []SearchHit, options *SearchOptions) {
+	if options == nil || options.RoundScoresTo == nil {
+		return
+	}
+	for index := range hits {
+		hits[index].Value = RoundScore(hits[index].Value, *options.RoundScoresTo)
+	}
+}
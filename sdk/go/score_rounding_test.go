@@ -0,0 +1,56 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoundScore(t *testing.T) {
+	t.Parallel()
+
+	if got := RoundScore(0.123456, 3); got != float32(0.123) {
+		t.Fatalf("unexpected rounding: %v", got)
+	}
+	if got := RoundScore(0.98765, 2); got != float32(0.99) {
+		t.Fatalf("unexpected rounding: %v", got)
+	}
+	if got := RoundScore(0.5, -1); got != 0.5 {
+		t.Fatalf("expected negative decimals to keep the value, got: %v", got)
+	}
+}
+
+func TestSearchRoundsScoresWhenConfigured(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writeJSON(t, writer, map[string]any{
+			"metric": "dot",
+			"mode":   "exact",
+			"hits": []map[string]any{
+				{"id": 1, "value": 0.876543},
+				{"id": 2, "value": 0.123456},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	options := &SearchTopKOptions{SearchOptions: SearchOptions{RoundScoresTo: IntPtr(2)}}
+	response, err := client.SearchCollectionTopK(context.Background(), "demo", []float32{1, 0}, options)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if response.Hits[0].Value != float32(0.88) || response.Hits[1].Value != float32(0.12) {
+		t.Fatalf("scores were not rounded: %#v", response.Hits)
+	}
+
+	raw, err := client.SearchCollectionTopK(context.Background(), "demo", []float32{1, 0}, nil)
+	if err != nil {
+		t.Fatalf("search without rounding failed: %v", err)
+	}
+	if raw.Hits[0].Value != float32(0.876543) {
+		t.Fatalf("expected unrounded score without the option, got: %v", raw.Hits[0].Value)
+	}
+}
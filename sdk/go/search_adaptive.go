@@ -0,0 +1,35 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+)
+
+// SearchModeAdaptive is resolved by the client, never sent: collections with
+// fewer points than ClientOptions.AdaptiveExactThreshold are searched with
+// SearchModeExact and larger ones with SearchModeIVF. The point count comes
+// from the metadata cache, so it can lag behind recent writes by up to
+// MetadataCacheTTL.
+const SearchModeAdaptive SearchMode = "adaptive"
+
+const defaultAdaptiveExactThreshold = 10_000
+
+func (c *Client) resolveAdaptiveMode(ctx context.Context, collection string, body map[string]any, opts []CallOption) error {
+	if body["mode"] != SearchModeAdaptive {
+		return nil
+	}
+	metadata, err := c.collectionMetadata(ctx, collection, opts...)
+	if err != nil {
+		return fmt.Errorf("resolve adaptive search mode: %w", err)
+	}
+	threshold := c.adaptiveExactThreshold
+	if threshold <= 0 {
+		threshold = defaultAdaptiveExactThreshold
+	}
+	if metadata.PointCount < threshold {
+		body["mode"] = SearchModeExact
+	} else {
+		body["mode"] = SearchModeIVF
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSearchModeAdaptiveResolvesModeFromPointCount(t *testing.T) {
+	t.Parallel()
+
+	pointCounts := map[string]int{"small": 50, "large": 50_000}
+	var mu sync.Mutex
+	metadataFetches := 0
+	modes := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		name := strings.Split(strings.TrimPrefix(request.URL.Path, "/collections/"), "/")[0]
+		if request.Method == http.MethodGet {
+			mu.Lock()
+			metadataFetches++
+			mu.Unlock()
+			writeJSON(t, writer, map[string]any{"name": name, "dimension": 2, "point_count": pointCounts[name]})
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		mu.Lock()
+		modes[name], _ = body["mode"].(string)
+		mu.Unlock()
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": body["mode"], "hits": []map[string]any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{DefaultMode: SearchModeAdaptive, AdaptiveExactThreshold: 1000})
+	for range 2 {
+		for name := range pointCounts {
+			if _, err := client.SearchCollectionTopK(context.Background(), name, []float32{1, 2}, nil); err != nil {
+				t.Fatalf("search %s failed: %v", name, err)
+			}
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if modes["small"] != "exact" || modes["large"] != "ivf" {
+		t.Fatalf("unexpected resolved modes: %v", modes)
+	}
+	if metadataFetches != 2 {
+		t.Fatalf("expected point counts to be cached, got %d metadata fetches", metadataFetches)
+	}
+}
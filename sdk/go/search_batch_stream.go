@@ -0,0 +1,123 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SearchTopKBatchStream runs a batch top-k search and decodes the "results"
+// array one item at a time, calling fn with each item and its query index as
+// soon as it has been parsed, so the whole response is never held in memory.
+// An error from fn stops the stream and is returned. Requests are not
+// retried; with AutoChunkBatches the chunks are streamed one after another.
+// ExcludeIDs are always filtered locally as well, widening the limit on
+// servers known to ignore them.
+func (c *Client) SearchTopKBatchStream(ctx context.Context, collection string, queries [][]float32, options *SearchTopKOptions, fn func(index int, item SearchTopKBatchItem) error, opts ...CallOption) error {
+	if err := validateBatchQueries(queries); err != nil {
+		return err
+	}
+	if err := c.checkBatchSize(len(queries)); err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	chunkSize := len(queries)
+	if c.shouldChunkBatch(len(queries)) {
+		chunkSize = c.maxBatchItems
+	}
+	for start := 0; start < len(queries); start += chunkSize {
+		end := min(start+chunkSize, len(queries))
+		if err := c.streamTopKBatch(ctx, collection, queries[start:end], start, options, fn, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamTopKBatch streams one batch request, reporting item indexes shifted
+// by offset.
+func (c *Client) streamTopKBatch(ctx context.Context, collection string, queries [][]float32, offset int, options *SearchTopKOptions, fn func(int, SearchTopKBatchItem) error, opts []CallOption) error {
+	body, err := c.searchTopKBody(queries[0], options)
+	if err != nil {
+		return err
+	}
+	if err := c.applyCollectionDefaults(ctx, collection, body, topKSearchOptions(options), opts); err != nil {
+		return err
+	}
+	body["queries"] = queries
+	delete(body, "query")
+	limit := requestedLimit(body)
+	excluded := excludedIDSet(topKSearchOptions(options))
+	if len(excluded) > 0 && !c.capabilities.supported(capabilityExcludeIDs) {
+		body = widenedLimitBody(body, limit+len(excluded))
+	}
+
+	path := fmt.Sprintf("/collections/%s/search/topk/batch", collectionPathSegment(collection))
+	encoded, err := c.encodeBody(body)
+	if err != nil {
+		return &Error{Method: http.MethodPost, Path: path, Err: err}
+	}
+	stream, err := c.openStream(ctx, http.MethodPost, path, encoded, "application/json", newCallConfig(opts))
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	decoder := json.NewDecoder(stream)
+	invalid := func(err error) error {
+		return &Error{Method: http.MethodPost, Path: path, Err: fmt.Errorf("invalid JSON response: %w", err)}
+	}
+	if err := expectDelim(decoder, '{'); err != nil {
+		return invalid(err)
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return invalid(err)
+		}
+		if token != "results" {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return invalid(err)
+			}
+			continue
+		}
+		if err := expectDelim(decoder, '['); err != nil {
+			return invalid(err)
+		}
+		for index := offset; decoder.More(); index++ {
+			var item SearchTopKBatchItem
+			if err := decoder.Decode(&item); err != nil {
+				return invalid(err)
+			}
+			if hitsLeakExcluded(item.Hits, excluded) {
+				c.capabilities.markUnsupported(capabilityExcludeIDs)
+			}
+			if len(excluded) > 0 {
+				item.Hits = trimHits(withoutHitIDs(item.Hits, excluded), limit)
+			}
+			roundHits(item.Hits, topKSearchOptions(options))
+			if err := fn(index, item); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(decoder, ']'); err != nil {
+			return invalid(err)
+		}
+	}
+	return nil
+}
+
+func expectDelim(decoder *json.Decoder, want json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != want {
+		return fmt.Errorf("expected %q, got %v", want, token)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSearchTopKBatchStreamDeliversItemsAsParsed(t *testing.T) {
+	t.Parallel()
+
+	delivered := make(chan int)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body struct {
+			Queries [][]float32 `json:"queries"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		flusher := writer.(http.Flusher)
+		_, _ = fmt.Fprint(writer, `{"metric":"dot","results":[`)
+		for index := range body.Queries {
+			if index > 0 {
+				_, _ = fmt.Fprint(writer, ",")
+			}
+			_, _ = fmt.Fprintf(writer, `{"mode":"exact","hits":[{"id":%d,"value":0.123}]}`, 100+index)
+			flusher.Flush()
+			// The next item is only written once the client has handled this
+			// one, so a buffering client would deadlock here.
+			select {
+			case got := <-delivered:
+				if got != index {
+					t.Errorf("expected item %d to be delivered, got %d", index, got)
+				}
+			case <-time.After(5 * time.Second):
+				t.Errorf("item %d was not delivered before the response ended", index)
+				return
+			}
+		}
+		_, _ = fmt.Fprint(writer, `]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	queries := [][]float32{{1, 0}, {0, 1}, {1, 1}}
+	options := &SearchTopKOptions{SearchOptions: SearchOptions{RoundScoresTo: IntPtr(1)}}
+	var indexes []int
+	err := client.SearchTopKBatchStream(context.Background(), "demo", queries, options, func(index int, item SearchTopKBatchItem) error {
+		if item.Hits[0].ID != uint64(100+index) || item.Hits[0].Value != 0.1 {
+			t.Errorf("item %d: unexpected hits %+v", index, item.Hits)
+		}
+		indexes = append(indexes, index)
+		delivered <- index
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchTopKBatchStream failed: %v", err)
+	}
+	if len(indexes) != len(queries) {
+		t.Fatalf("expected %d items, got %v", len(queries), indexes)
+	}
+}
+
+func TestSearchTopKBatchStreamChunksAndStopsOnCallbackError(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		var body struct {
+			Queries [][]float32 `json:"queries"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		results := make([]map[string]any, len(body.Queries))
+		for index := range results {
+			results[index] = map[string]any{"mode": "exact", "hits": []map[string]any{}}
+		}
+		writeJSON(t, writer, map[string]any{"metric": "dot", "results": results})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{MaxBatchItems: 2, AutoChunkBatches: true})
+	queries := [][]float32{{1}, {2}, {3}, {4}, {5}}
+	var indexes []int
+	stop := errors.New("stop")
+	err := client.SearchTopKBatchStream(context.Background(), "demo", queries, nil, func(index int, item SearchTopKBatchItem) error {
+		indexes = append(indexes, index)
+		if index == 3 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected the callback error, got %v", err)
+	}
+	if fmt.Sprint(indexes) != "[0 1 2 3]" || requests != 2 {
+		t.Fatalf("expected indexes [0 1 2 3] over 2 requests, got %v over %d", indexes, requests)
+	}
+}
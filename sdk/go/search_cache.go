@@ -0,0 +1,144 @@
+package aionbd
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSearchCacheEntries = 256
+	defaultSearchCacheTTL     = 10 * time.Second
+)
+
+// SearchCacheConfig enables an in-memory LRU cache for SearchCollectionTopK.
+// Entries are keyed by the collection, the credentials and headers, and the
+// canonical request body (query vector, limit, metric, mode, filter, ...),
+// and expire after TTL. Calls using WithRawCapture bypass the cache. Cached
+// responses share payload maps, so treat them as read-only.
+type SearchCacheConfig struct {
+	MaxEntries int
+	TTL        time.Duration
+}
+
+type searchCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type searchCacheEntry struct {
+	key       string
+	response  SearchTopKResponse
+	expiresAt time.Time
+}
+
+func newSearchCache(config *SearchCacheConfig) *searchCache {
+	if config == nil {
+		return nil
+	}
+	cache := &searchCache{
+		maxEntries: config.MaxEntries,
+		ttl:        config.TTL,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+	if cache.maxEntries <= 0 {
+		cache.maxEntries = defaultSearchCacheEntries
+	}
+	if cache.ttl <= 0 {
+		cache.ttl = defaultSearchCacheTTL
+	}
+	return cache
+}
+
+// searchCacheKey hashes the request identity, including credentials and every
+// header source so tenants never share entries; ok is false when the body
+// cannot be encoded canonically, in which case the search is not cached.
+func (c *Client) searchCacheKey(ctx context.Context, path string, body map[string]any, call *callConfig) (string, bool) {
+	encoded, err := canonicalJSON(body)
+	if err != nil {
+		return "", false
+	}
+	scope, ok := c.requestScope(ctx, call)
+	if !ok {
+		return "", false
+	}
+
+	hash := sha256.New()
+	hash.Write(scope)
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(encoded)
+	return hex.EncodeToString(hash.Sum(nil)), true
+}
+
+// requestScope identifies who a request is made as: the credentials and every
+// header source. Cached responses are only shared within one scope.
+func (c *Client) requestScope(ctx context.Context, call *callConfig) ([]byte, bool) {
+	headers := map[string]any{"default": c.defaultHeader, "multi": c.multiHeader, "call": call.header}
+	if ctx != nil {
+		headers["context"] = contextHeaders(ctx)
+	}
+	encodedHeaders, err := canonicalJSON(headers)
+	if err != nil {
+		return nil, false
+	}
+
+	var scope []byte
+	for _, part := range [][]byte{[]byte(c.apiKey), []byte(c.bearerToken), encodedHeaders} {
+		scope = append(scope, part...)
+		scope = append(scope, 0)
+	}
+	return scope, true
+}
+
+func (cache *searchCache) get(key string) (SearchTopKResponse, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	element, found := cache.entries[key]
+	if !found {
+		return SearchTopKResponse{}, false
+	}
+	entry := element.Value.(*searchCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		cache.order.Remove(element)
+		delete(cache.entries, key)
+		return SearchTopKResponse{}, false
+	}
+	cache.order.MoveToFront(element)
+	return copySearchTopKResponse(entry.response), true
+}
+
+func (cache *searchCache) put(key string, response SearchTopKResponse) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry := &searchCacheEntry{
+		key:       key,
+		response:  copySearchTopKResponse(response),
+		expiresAt: time.Now().Add(cache.ttl),
+	}
+	if element, found := cache.entries[key]; found {
+		element.Value = entry
+		cache.order.MoveToFront(element)
+		return
+	}
+	cache.entries[key] = cache.order.PushFront(entry)
+	for cache.order.Len() > cache.maxEntries {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*searchCacheEntry).key)
+	}
+}
+
+func copySearchTopKResponse(response SearchTopKResponse) SearchTopKResponse {
+	response.Hits = append([]SearchHit(nil), response.Hits...)
+	return response
+}
@@ -0,0 +1,78 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSearchCacheServesIdenticalQueriesWithinTTL(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		calls.Add(1)
+		writeJSON(t, writer, map[string]any{
+			"metric": "dot",
+			"mode":   "exact",
+			"hits":   []map[string]any{{"id": 1, "value": 0.9}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{SearchCache: &SearchCacheConfig{TTL: time.Minute}})
+	ctx := context.Background()
+	query := []float32{1, 0}
+	options := &SearchTopKOptions{Limit: IntPtr(5)}
+
+	first, err := client.SearchCollectionTopK(ctx, "demo", query, options)
+	if err != nil {
+		t.Fatalf("first search failed: %v", err)
+	}
+	second, err := client.SearchCollectionTopK(ctx, "demo", query, options)
+	if err != nil {
+		t.Fatalf("second search failed: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected identical search to be served from cache, got %d calls", calls.Load())
+	}
+	if len(second.Hits) != 1 || second.Hits[0].ID != first.Hits[0].ID {
+		t.Fatalf("unexpected cached response: %#v", second)
+	}
+
+	if _, err := client.SearchCollectionTopK(ctx, "demo", query, &SearchTopKOptions{Limit: IntPtr(6)}); err != nil {
+		t.Fatalf("search with changed limit failed: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected changed limit to miss the cache, got %d calls", calls.Load())
+	}
+
+	if _, err := client.SearchCollectionTopK(ctx, "demo", query, options, WithNoCache()); err != nil {
+		t.Fatalf("search with no cache failed: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected WithNoCache to bypass the cache, got %d calls", calls.Load())
+	}
+}
+
+func TestSearchCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	cache := newSearchCache(&SearchCacheConfig{MaxEntries: 2, TTL: time.Minute})
+	cache.put("a", SearchTopKResponse{Metric: MetricDot})
+	cache.put("b", SearchTopKResponse{Metric: MetricL2})
+	if _, found := cache.get("a"); !found {
+		t.Fatal("expected a to be cached")
+	}
+	cache.put("c", SearchTopKResponse{Metric: MetricCosine})
+
+	if _, found := cache.get("b"); found {
+		t.Fatal("expected least recently used entry b to be evicted")
+	}
+	if _, found := cache.get("a"); !found {
+		t.Fatal("expected a to survive eviction")
+	}
+}
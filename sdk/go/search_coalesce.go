@@ -0,0 +1,94 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// searchCoalescer lets concurrent identical SearchCollectionTopK calls share
+// one request. Each caller gets its own copy of the response.
+type searchCoalescer struct {
+	mu      sync.Mutex
+	flights map[string]*searchFlight
+}
+
+type searchFlight struct {
+	done chan struct{}
+	// waiters counts the callers that joined this flight instead of sending
+	// their own request; see callers.
+	waiters  int
+	response SearchTopKResponse
+	err      error
+}
+
+func newSearchCoalescer(enabled bool) *searchCoalescer {
+	if !enabled {
+		return nil
+	}
+	return &searchCoalescer{flights: make(map[string]*searchFlight)}
+}
+
+// callers reports how many searches are currently in flight or waiting on
+// one, and how many of those share another caller's request. Tests use it to
+// tell that concurrent searches were coalesced.
+func (coalescer *searchCoalescer) callers() (total int, sharing int) {
+	coalescer.mu.Lock()
+	defer coalescer.mu.Unlock()
+	for _, flight := range coalescer.flights {
+		total += 1 + flight.waiters
+		sharing += flight.waiters
+	}
+	return total, sharing
+}
+
+// do runs fn unless an identical search is in flight, in which case it waits
+// for that one. A follower whose own context is still live runs fn itself if
+// the shared request failed because the leader's context ended.
+func (coalescer *searchCoalescer) do(ctx context.Context, key string, fn func() (SearchTopKResponse, error)) (SearchTopKResponse, error) {
+	coalescer.mu.Lock()
+	if flight, found := coalescer.flights[key]; found {
+		flight.waiters++
+		coalescer.mu.Unlock()
+		select {
+		case <-flight.done:
+		case <-ctx.Done():
+			return SearchTopKResponse{}, ctx.Err()
+		}
+		if isContextError(flight.err) && ctx.Err() == nil {
+			return fn()
+		}
+		return copySearchTopKResponse(flight.response), flight.err
+	}
+	flight := &searchFlight{done: make(chan struct{})}
+	coalescer.flights[key] = flight
+	coalescer.mu.Unlock()
+
+	response, err := fn()
+	flight.response, flight.err = copySearchTopKResponse(response), err
+	coalescer.mu.Lock()
+	delete(coalescer.flights, key)
+	coalescer.mu.Unlock()
+	close(flight.done)
+	return response, err
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// coalescedSearchTopK keys in-flight searches like the search cache, plus the
+// node chosen by WithNode and the WithQueryTag tag, so each tag still reaches
+// the server. Raw captures and session-bound calls are not shared.
+func (c *Client) coalescedSearchTopK(ctx context.Context, path string, body map[string]any, options *SearchTopKOptions, call *callConfig, opts []CallOption) (SearchTopKResponse, error) {
+	if c.coalescer == nil || call.capturesResponse() || sessionFrom(ctx) != nil {
+		return c.searchTopK(ctx, path, body, options, opts)
+	}
+	key, ok := c.searchCacheKey(ctx, path, body, call)
+	if !ok {
+		return c.searchTopK(ctx, path, body, options, opts)
+	}
+	return c.coalescer.do(ctx, key+"\x00"+call.node+"\x00"+queryTag(ctx), func() (SearchTopKResponse, error) {
+		return c.searchTopK(ctx, path, body, options, opts)
+	})
+}
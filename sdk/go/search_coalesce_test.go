@@ -0,0 +1,60 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCoalesceSearchesSharesOneRequest(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		<-release
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": []map[string]any{{"id": 7, "value": 0.123456}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{CoalesceSearches: true})
+	options := &SearchTopKOptions{SearchOptions: SearchOptions{RoundScoresTo: IntPtr(2)}, Limit: IntPtr(3)}
+
+	const callers = 50
+	var finished sync.WaitGroup
+	responses := make([]SearchTopKResponse, callers)
+	errs := make([]error, callers)
+	for index := range callers {
+		finished.Add(1)
+		go func() {
+			defer finished.Done()
+			responses[index], errs[index] = client.SearchCollectionTopK(context.Background(), "demo", []float32{1, 2}, options)
+		}()
+	}
+	for total, sharing := 0, 0; total < callers; {
+		total, sharing = client.coalescer.callers()
+		if total == callers && sharing != callers-1 {
+			t.Fatalf("expected %d callers to share one request, got %d", callers-1, sharing)
+		}
+		runtime.Gosched()
+	}
+	close(release)
+	finished.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected exactly one HTTP request, got %d", got)
+	}
+	for index := range callers {
+		if errs[index] != nil {
+			t.Fatalf("caller %d: %v", index, errs[index])
+		}
+		if len(responses[index].Hits) != 1 || responses[index].Hits[0].Value != 0.12 {
+			t.Fatalf("caller %d: unexpected response %+v", index, responses[index])
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Exclusions are sent to the server as "exclude_ids". Servers without native
+// support ignore the field, which shows up as an excluded ID among the hits.
+// In that case the search is re-issued with the limit widened by the number of
+// excluded IDs and the hits are filtered locally; the client then remembers
+// the missing capability and goes straight to the fallback. With approximate
+// (IVF) searches the fallback can return fewer relevant hits than a native
+// exclusion, so recall may drop.
+
+func excludedIDSet(options *SearchOptions) map[uint64]struct{} {
+	if options == nil || len(options.ExcludeIDs) == 0 {
+		return nil
+	}
+	excluded := make(map[uint64]struct{}, len(options.ExcludeIDs))
+	for _, id := range options.ExcludeIDs {
+		excluded[id] = struct{}{}
+	}
+	return excluded
+}
+
+func isExcluded(excluded map[uint64]struct{}, id uint64) bool {
+	_, found := excluded[id]
+	return found
+}
+
+func hitsLeakExcluded(hits []SearchHit, excluded map[uint64]struct{}) bool {
+	for _, hit := range hits {
+		if isExcluded(excluded, hit.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) searchWithoutExcluded(ctx context.Context, collection string, query []float32, options *SearchOptions, opts []CallOption) (SearchResponse, error) {
+	topK, err := c.SearchCollectionTopK(ctx, collection, query, &SearchTopKOptions{
+		SearchOptions: *options,
+		Limit:         IntPtr(1),
+	}, opts...)
+	if err != nil {
+		return SearchResponse{}, err
+	}
+	if len(topK.Hits) == 0 {
+		return SearchResponse{}, fmt.Errorf("no search hits remain after excluding %d ids", len(options.ExcludeIDs))
+	}
+	best := topK.Hits[0]
+	return SearchResponse{
+		ID:        best.ID,
+		Metric:    topK.Metric,
+		Value:     best.Value,
+		Mode:      topK.Mode,
+		RecallAtK: topK.RecallAtK,
+		Payload:   best.Payload,
+	}, nil
+}
+
+func (c *Client) searchTopKWithoutExcluded(ctx context.Context, path string, body map[string]any, excluded map[uint64]struct{}, opts []CallOption) (SearchTopKResponse, error) {
+	limit := requestedLimit(body)
+	widened := widenedLimitBody(body, limit+len(excluded))
+	var response SearchTopKResponse
+	if err := c.requestJSON(ctx, http.MethodPost, path, widened, &response, opts...); err != nil {
+		return response, err
+	}
+	response.Hits = trimHits(withoutHitIDs(response.Hits, excluded), limit)
+	return response, nil
+}
+
+func (c *Client) searchTopKBatchWithoutExcluded(ctx context.Context, path string, body map[string]any, excluded map[uint64]struct{}, opts []CallOption) (SearchTopKBatchResponse, error) {
+	limit := requestedLimit(body)
+	widened := widenedLimitBody(body, limit+len(excluded))
+	var response SearchTopKBatchResponse
+	if err := c.requestJSON(ctx, http.MethodPost, path, widened, &response, opts...); err != nil {
+		return response, err
+	}
+	for index := range response.Results {
+		hits := withoutHitIDs(response.Results[index].Hits, excluded)
+		response.Results[index].Hits = trimHits(hits, limit)
+	}
+	return response, nil
+}
+
+func requestedLimit(body map[string]any) int {
+	if limit, ok := body["limit"].(int); ok {
+		return limit
+	}
+	return defaultTopKLimit
+}
+
+func widenedLimitBody(body map[string]any, limit int) map[string]any {
+	widened := make(map[string]any, len(body))
+	for key, value := range body {
+		widened[key] = value
+	}
+	delete(widened, "exclude_ids")
+	widened["limit"] = limit
+	return widened
+}
+
+func trimHits(hits []SearchHit, limit int) []SearchHit {
+	if len(hits) > limit {
+		return hits[:limit]
+	}
+	return hits
+}
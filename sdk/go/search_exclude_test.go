@@ -0,0 +1,70 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestSearchCollectionTopKExcludesIDs(t *testing.T) {
+	t.Parallel()
+
+	for _, native := range []bool{true, false} {
+		var mu sync.Mutex
+		var limits []int
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			var body struct {
+				Limit      int      `json:"limit"`
+				ExcludeIDs []uint64 `json:"exclude_ids"`
+			}
+			if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+				t.Fatalf("decode request body: %v", err)
+			}
+			mu.Lock()
+			limits = append(limits, body.Limit)
+			mu.Unlock()
+
+			excluded := map[uint64]bool{}
+			if native {
+				for _, id := range body.ExcludeIDs {
+					excluded[id] = true
+				}
+			}
+			hits := []map[string]any{}
+			for id := uint64(1); id <= 6 && len(hits) < body.Limit; id++ {
+				if !excluded[id] {
+					hits = append(hits, map[string]any{"id": id, "value": 1 - float64(id)/10})
+				}
+			}
+			writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": hits})
+		}))
+
+		client := NewClient(server.URL, nil)
+		response, err := client.SearchCollectionTopK(context.Background(), "demo", []float32{1, 0}, &SearchTopKOptions{
+			SearchOptions: SearchOptions{ExcludeIDs: []uint64{1, 3}},
+			Limit:         IntPtr(3),
+		})
+		server.Close()
+		if err != nil {
+			t.Fatalf("search top-k failed (native=%v): %v", native, err)
+		}
+
+		if len(response.Hits) != 3 {
+			t.Fatalf("unexpected hits (native=%v): %#v", native, response.Hits)
+		}
+		for _, hit := range response.Hits {
+			if hit.ID == 1 || hit.ID == 3 {
+				t.Fatalf("excluded id %d leaked (native=%v): %#v", hit.ID, native, response.Hits)
+			}
+		}
+		if native && len(limits) != 1 {
+			t.Fatalf("expected a single native request, got limits %v", limits)
+		}
+		if !native && (len(limits) != 2 || limits[1] != 5) {
+			t.Fatalf("expected a widened fallback request, got limits %v", limits)
+		}
+	}
+}
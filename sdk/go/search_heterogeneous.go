@@ -0,0 +1,125 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const heterogeneousSearchConcurrency = 8
+
+// SearchRequest is one query of SearchTopKBatchHeterogeneous with its own
+// limit, filter, mode, and other top-k options.
+type SearchRequest struct {
+	Query []float32
+	SearchTopKOptions
+}
+
+// SearchTopKBatchHeterogeneous runs requests that may differ in their options.
+// The server's batch endpoint applies one set of options to every query, so
+// requests with identical options are grouped into one batch call and the
+// groups (or lone requests, as single top-k calls) run concurrently. Results
+// are returned in request order.
+func (c *Client) SearchTopKBatchHeterogeneous(ctx context.Context, collection string, requests []SearchRequest, opts ...CallOption) ([]SearchTopKResponse, error) {
+	groups, err := c.groupSearchRequests(requests)
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]SearchTopKResponse, len(requests))
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	slots := make(chan struct{}, heterogeneousSearchConcurrency)
+	for _, group := range groups {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(indexes []int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := c.searchRequestGroup(ctx, collection, requests, indexes, results, opts); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(group)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+// groupSearchRequests validates every request and buckets request indexes by
+// their encoded options, preserving first-seen order.
+func (c *Client) groupSearchRequests(requests []SearchRequest) ([][]int, error) {
+	var groups [][]int
+	byKey := make(map[string]int)
+	for index, request := range requests {
+		options := request.SearchTopKOptions
+		body, err := c.searchTopKBody(request.Query, &options)
+		if err != nil {
+			return nil, fmt.Errorf("requests[%d]: %w", index, err)
+		}
+		delete(body, "query")
+		if options.RoundScoresTo != nil {
+			body["round_scores_to"] = *options.RoundScoresTo
+		}
+		encoded, err := canonicalJSON(body)
+		if err != nil {
+			return nil, fmt.Errorf("requests[%d]: %w", index, err)
+		}
+		key := string(encoded)
+		position, found := byKey[key]
+		if !found {
+			position = len(groups)
+			byKey[key] = position
+			groups = append(groups, nil)
+		}
+		groups[position] = append(groups[position], index)
+	}
+	return groups, nil
+}
+
+func (c *Client) searchRequestGroup(ctx context.Context, collection string, requests []SearchRequest, indexes []int, results []SearchTopKResponse, opts []CallOption) error {
+	options := requests[indexes[0]].SearchTopKOptions
+	if len(indexes) == 1 {
+		response, err := c.SearchCollectionTopK(ctx, collection, requests[indexes[0]].Query, &options, opts...)
+		if err != nil {
+			return err
+		}
+		results[indexes[0]] = response
+		return nil
+	}
+
+	queries := make([][]float32, len(indexes))
+	for position, index := range indexes {
+		queries[position] = requests[index].Query
+	}
+	response, err := c.SearchCollectionTopKBatch(ctx, collection, queries, &options, opts...)
+	if err != nil {
+		return err
+	}
+	if len(response.Results) != len(indexes) {
+		return fmt.Errorf("batch search returned %d results for %d queries", len(response.Results), len(indexes))
+	}
+	for position, index := range indexes {
+		item := response.Results[position]
+		results[index] = SearchTopKResponse{
+			Metric:    response.Metric,
+			Mode:      item.Mode,
+			RecallAtK: item.RecallAtK,
+			Hits:      item.Hits,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func hitsUpTo(limit int, offset int) []map[string]any {
+	hits := make([]map[string]any, limit)
+	for index := range hits {
+		hits[index] = map[string]any{"id": offset + index, "value": 1.0}
+	}
+	return hits
+}
+
+func TestSearchTopKBatchHeterogeneousRespectsPerQueryLimits(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	paths := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body struct {
+			Queries [][]float32 `json:"queries"`
+			Query   []float32   `json:"query"`
+			Limit   int         `json:"limit"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		mu.Lock()
+		paths[request.URL.Path]++
+		mu.Unlock()
+
+		switch request.URL.Path {
+		case "/collections/demo/search/topk":
+			writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": hitsUpTo(body.Limit, int(body.Query[0])*100)})
+		case "/collections/demo/search/topk/batch":
+			results := make([]map[string]any, len(body.Queries))
+			for index, query := range body.Queries {
+				results[index] = map[string]any{"mode": "exact", "hits": hitsUpTo(body.Limit, int(query[0])*100)}
+			}
+			writeJSON(t, writer, map[string]any{"metric": "dot", "results": results})
+		default:
+			t.Errorf("unexpected path: %s", request.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	requests := []SearchRequest{
+		{Query: []float32{1, 0}, SearchTopKOptions: SearchTopKOptions{Limit: IntPtr(2)}},
+		{Query: []float32{2, 0}, SearchTopKOptions: SearchTopKOptions{Limit: IntPtr(5), SearchOptions: SearchOptions{Mode: SearchModeExact}}},
+		{Query: []float32{3, 0}, SearchTopKOptions: SearchTopKOptions{Limit: IntPtr(2)}},
+	}
+	responses, err := client.SearchTopKBatchHeterogeneous(context.Background(), "demo", requests)
+	if err != nil {
+		t.Fatalf("heterogeneous batch failed: %v", err)
+	}
+
+	for index, expected := range []int{2, 5, 2} {
+		if len(responses[index].Hits) != expected {
+			t.Fatalf("request %d: expected %d hits, got %d", index, expected, len(responses[index].Hits))
+		}
+		if first := responses[index].Hits[0].ID; first != uint64((index+1)*100) {
+			t.Fatalf("request %d: hits belong to another query: first id %d", index, first)
+		}
+	}
+	if paths["/collections/demo/search/topk/batch"] != 1 || paths["/collections/demo/search/topk"] != 1 {
+		t.Fatalf("expected one grouped batch and one single search, got: %#v", paths)
+	}
+}
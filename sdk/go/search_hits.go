@@ -0,0 +1,47 @@
+package aionbd
+
+import (
+	"math"
+	"reflect"
+)
+
+// Equal reports whether both hits share an ID and payload and their scores
+// differ by at most epsilon. Payloads are compared with reflect.DeepEqual, so a
+// nil payload only equals another nil payload.
+func (h SearchHit) Equal(other SearchHit, epsilon float32) bool {
+	if h.ID != other.ID {
+		return false
+	}
+	if math.Abs(float64(h.Value)-float64(other.Value)) > float64(epsilon) {
+		return false
+	}
+	return reflect.DeepEqual(h.Payload, other.Payload)
+}
+
+// HitsEqual reports whether both slices hold pairwise Equal hits in the same order.
+func HitsEqual(a, b []SearchHit, epsilon float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for index := range a {
+		if !a[index].Equal(b[index], epsilon) {
+			return false
+		}
+	}
+	return true
+}
+
+// Best returns the top-ranked hit: the lowest value for MetricL2 (a distance)
+// and the highest otherwise. It does not rely on the server's hit order.
+func (r SearchTopKResponse) Best() (SearchHit, bool) {
+	if len(r.Hits) == 0 {
+		return SearchHit{}, false
+	}
+	best := r.Hits[0]
+	for _, hit := range r.Hits[1:] {
+		if r.Metric == MetricL2 && hit.Value < best.Value || r.Metric != MetricL2 && hit.Value > best.Value {
+			best = hit
+		}
+	}
+	return best, true
+}
@@ -0,0 +1,47 @@
+package aionbd
+
+import "testing"
+
+func TestSearchHitEqualToleratesScoreEpsilon(t *testing.T) {
+	t.Parallel()
+
+	hit := SearchHit{ID: 1, Value: 0.5, Payload: PointPayload{"label": "alpha", "tags": []any{"a"}}}
+	near := SearchHit{ID: 1, Value: 0.5004, Payload: PointPayload{"label": "alpha", "tags": []any{"a"}}}
+	far := SearchHit{ID: 1, Value: 0.52, Payload: hit.Payload}
+	otherPayload := SearchHit{ID: 1, Value: 0.5, Payload: PointPayload{"label": "beta", "tags": []any{"a"}}}
+
+	if !hit.Equal(near, 0.001) {
+		t.Fatal("expected scores within epsilon to be equal")
+	}
+	if hit.Equal(far, 0.001) {
+		t.Fatal("expected scores outside epsilon to differ")
+	}
+	if hit.Equal(otherPayload, 0.001) {
+		t.Fatal("expected payload mismatch to differ")
+	}
+	if hit.Equal(SearchHit{ID: 2, Value: 0.5, Payload: hit.Payload}, 0.001) {
+		t.Fatal("expected id mismatch to differ")
+	}
+
+	if !HitsEqual([]SearchHit{hit, far}, []SearchHit{near, far}, 0.001) {
+		t.Fatal("expected hit slices to be equal")
+	}
+	if HitsEqual([]SearchHit{hit}, []SearchHit{hit, far}, 0.001) {
+		t.Fatal("expected length mismatch to differ")
+	}
+}
+
+func TestSearchTopKResponseBestFollowsMetricDirection(t *testing.T) {
+	t.Parallel()
+
+	hits := []SearchHit{{ID: 1, Value: 0.5}, {ID: 2, Value: 0.1}, {ID: 3, Value: 0.9}}
+	if best, ok := (SearchTopKResponse{Metric: MetricL2, Hits: hits}).Best(); !ok || best.ID != 2 {
+		t.Fatalf("expected id 2 for l2, got %+v (ok=%v)", best, ok)
+	}
+	if best, ok := (SearchTopKResponse{Metric: MetricDot, Hits: hits}).Best(); !ok || best.ID != 3 {
+		t.Fatalf("expected id 3 for dot, got %+v (ok=%v)", best, ok)
+	}
+	if _, ok := (SearchTopKResponse{Metric: MetricDot}).Best(); ok {
+		t.Fatal("expected no best hit for an empty response")
+	}
+}
@@ -0,0 +1,171 @@
+package aionbd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const defaultMultiSearchConcurrency = 4
+
+type SearchMultiOptions struct {
+	SearchTopKOptions
+	// Concurrency caps the searches in flight (default 4).
+	Concurrency int
+	// ContinueOnError keeps the results of the collections that succeeded
+	// when others fail, reporting the failures as a *MultiSearchError.
+	ContinueOnError bool
+}
+
+type MultiCollectionHit struct {
+	Collection string `json:"collection"`
+	SearchHit
+}
+
+type SearchMultiResponse struct {
+	Metric  Metric
+	Hits    []MultiCollectionHit
+	Results map[string]SearchTopKResponse
+}
+
+// MultiSearchError lists the collections whose search failed, keyed by name.
+type MultiSearchError struct {
+	Failed map[string]error
+}
+
+func (e *MultiSearchError) Error() string {
+	names := sortedErrorKeys(e.Failed)
+	messages := make([]string, len(names))
+	for index, name := range names {
+		messages[index] = fmt.Sprintf("%s: %v", name, e.Failed[name])
+	}
+	return fmt.Sprintf("search failed for %d collection(s): %s", len(names), strings.Join(messages, "; "))
+}
+
+func (e *MultiSearchError) Unwrap() []error {
+	names := sortedErrorKeys(e.Failed)
+	errs := make([]error, len(names))
+	for index, name := range names {
+		errs[index] = e.Failed[name]
+	}
+	return errs
+}
+
+func sortedErrorKeys(failed map[string]error) []string {
+	names := make([]string, 0, len(failed))
+	for name := range failed {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// SearchMultiCollectionTopK runs the same top-k search against each collection
+// concurrently and merges the hits into one ranking of at most Limit hits,
+// best first by metric direction; per-collection responses stay available in
+// Results. Collections whose responses report different metrics have scores
+// that cannot be ranked together: Hits is then left empty and an error names
+// the metrics, while Results still holds every response. By default the first
+// failure cancels the remaining searches and is returned alone. With ContinueOnError the merged response of the successful
+// collections is returned together with a *MultiSearchError. The requests
+// follow the server's X-RateLimit-Remaining and X-RateLimit-Reset headers.
+func (c *Client) SearchMultiCollectionTopK(ctx context.Context, collections []string, query []float32, options *SearchMultiOptions, opts ...CallOption) (SearchMultiResponse, error) {
+	if options == nil {
+		options = &SearchMultiOptions{}
+	}
+	if options.Concurrency < 0 {
+		return SearchMultiResponse{}, fmt.Errorf("concurrency must be a positive integer")
+	}
+	concurrency := options.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultMultiSearchConcurrency
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(withServerThrottle(ctx))
+	defer cancel()
+
+	var mu sync.Mutex
+	results := make(map[string]SearchTopKResponse, len(collections))
+	failed := make(map[string]error)
+	var firstErr error
+	names := make(chan string)
+	var workers sync.WaitGroup
+	for range min(concurrency, len(collections)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for name := range names {
+				response, err := c.SearchCollectionTopK(ctx, name, query, &options.SearchTopKOptions, opts...)
+				mu.Lock()
+				if err == nil {
+					results[name] = response
+				} else if options.ContinueOnError {
+					failed[name] = err
+				} else if firstErr == nil {
+					firstErr = fmt.Errorf("collection %s: %w", name, err)
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, name := range collections {
+		names <- strings.TrimSpace(name)
+	}
+	close(names)
+	workers.Wait()
+
+	if firstErr != nil {
+		return SearchMultiResponse{}, firstErr
+	}
+	response, mergeErr := mergeMultiResults(results, options.Limit)
+	if len(failed) > 0 {
+		return response, errors.Join(mergeErr, &MultiSearchError{Failed: failed})
+	}
+	return response, mergeErr
+}
+
+func mergeMultiResults(results map[string]SearchTopKResponse, limit *int) (SearchMultiResponse, error) {
+	merged := SearchMultiResponse{Results: results}
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var metricFrom string
+	for _, name := range names {
+		switch metric := results[name].Metric; {
+		case metric == "":
+		case merged.Metric == "":
+			merged.Metric, metricFrom = metric, name
+		case metric != merged.Metric:
+			return SearchMultiResponse{Results: results}, fmt.Errorf("cannot merge hits across metrics: collection %s uses %s, %s uses %s", metricFrom, merged.Metric, name, metric)
+		}
+	}
+	for _, name := range names {
+		for _, hit := range results[name].Hits {
+			merged.Hits = append(merged.Hits, MultiCollectionHit{Collection: name, SearchHit: hit})
+		}
+	}
+	slices.SortFunc(merged.Hits, func(a, b MultiCollectionHit) int {
+		order := cmp.Compare(b.Value, a.Value)
+		if merged.Metric == MetricL2 {
+			order = -order
+		}
+		return cmp.Or(order, strings.Compare(a.Collection, b.Collection), cmp.Compare(a.ID, b.ID))
+	})
+	maxHits := defaultTopKLimit
+	if limit != nil {
+		maxHits = *limit
+	}
+	if len(merged.Hits) > maxHits {
+		merged.Hits = merged.Hits[:maxHits]
+	}
+	return merged, nil
+}
@@ -0,0 +1,95 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newMultiSearchServer(t *testing.T) *httptest.Server {
+	hits := map[string][]map[string]any{
+		"books":  {{"id": 1, "value": 0.9}, {"id": 2, "value": 0.4}},
+		"movies": {{"id": 7, "value": 0.7}, {"id": 8, "value": 0.1}},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		name := strings.Split(strings.TrimPrefix(request.URL.Path, "/collections/"), "/")[0]
+		collectionHits, found := hits[name]
+		if !found {
+			writer.WriteHeader(http.StatusNotFound)
+			writeJSON(t, writer, map[string]any{"error": "collection not found"})
+			return
+		}
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": collectionHits})
+	}))
+}
+
+func TestSearchMultiCollectionTopKContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	server := newMultiSearchServer(t)
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	options := &SearchMultiOptions{SearchTopKOptions: SearchTopKOptions{Limit: IntPtr(3)}, ContinueOnError: true}
+	response, err := client.SearchMultiCollectionTopK(context.Background(), []string{"books", "missing", "movies"}, []float32{1, 2}, options)
+
+	var multiErr *MultiSearchError
+	if !errors.As(err, &multiErr) || len(multiErr.Failed) != 1 || !isStatus(multiErr.Failed["missing"], http.StatusNotFound) {
+		t.Fatalf("expected a MultiSearchError for the missing collection, got %v", err)
+	}
+	want := []MultiCollectionHit{
+		{Collection: "books", SearchHit: SearchHit{ID: 1, Value: 0.9}},
+		{Collection: "movies", SearchHit: SearchHit{ID: 7, Value: 0.7}},
+		{Collection: "books", SearchHit: SearchHit{ID: 2, Value: 0.4}},
+	}
+	if len(response.Hits) != len(want) {
+		t.Fatalf("expected %d merged hits, got %+v", len(want), response.Hits)
+	}
+	for index := range want {
+		if response.Hits[index].Collection != want[index].Collection || response.Hits[index].ID != want[index].ID {
+			t.Fatalf("expected merged hits %+v, got %+v", want, response.Hits)
+		}
+	}
+	if len(response.Results) != 2 || response.Metric != MetricDot {
+		t.Fatalf("unexpected per-collection results: %+v", response.Results)
+	}
+}
+
+func TestSearchMultiCollectionTopKFailsWholeQueryByDefault(t *testing.T) {
+	t.Parallel()
+
+	server := newMultiSearchServer(t)
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	response, err := client.SearchMultiCollectionTopK(context.Background(), []string{"books", "missing"}, []float32{1, 2}, nil)
+	if !isStatus(err, http.StatusNotFound) || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected the 404 of the missing collection, got %v", err)
+	}
+	if len(response.Hits) != 0 {
+		t.Fatalf("expected no results, got %+v", response)
+	}
+}
+
+func TestSearchMultiCollectionTopKRejectsMixedMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := map[string]string{"books": "dot", "places": "l2"}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		name := strings.Split(strings.TrimPrefix(request.URL.Path, "/collections/"), "/")[0]
+		writeJSON(t, writer, map[string]any{"metric": metrics[name], "mode": "exact", "hits": []map[string]any{{"id": 1, "value": 0.5}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	response, err := client.SearchMultiCollectionTopK(context.Background(), []string{"places", "books"}, []float32{1, 2}, nil)
+	if err == nil || !strings.Contains(err.Error(), "collection books uses dot, places uses l2") {
+		t.Fatalf("expected a mixed metric error, got %v", err)
+	}
+	if len(response.Hits) != 0 || len(response.Results) != 2 {
+		t.Fatalf("expected per-collection results without merged hits, got %+v", response)
+	}
+}
@@ -0,0 +1,50 @@
+package aionbd
+
+import (
+	"context"
+	"fmt"
+)
+
+const defaultTopKLimit = 10
+
+// SearchByPointID runs a top-k search using the stored vector of pointID as the
+// query. The source point is always excluded from the hits: one extra hit is
+// requested so the response still carries up to the requested limit.
+func (c *Client) SearchByPointID(ctx context.Context, collection string, pointID uint64, options *SearchTopKOptions, opts ...CallOption) (SearchTopKResponse, error) {
+	effective := SearchTopKOptions{}
+	limit := defaultTopKLimit
+	if options != nil {
+		effective = *options
+		if options.Limit != nil {
+			limit = *options.Limit
+		}
+	}
+	if limit <= 0 {
+		return SearchTopKResponse{}, fmt.Errorf("limit must be a positive integer")
+	}
+
+	point, err := c.GetPoint(ctx, collection, pointID, opts...)
+	if err != nil {
+		return SearchTopKResponse{}, err
+	}
+
+	effective.Limit = IntPtr(limit + 1)
+	response, err := c.SearchCollectionTopK(ctx, collection, point.Values, &effective, opts...)
+	if err != nil {
+		return SearchTopKResponse{}, err
+	}
+	hits := withoutHitIDs(response.Hits, map[uint64]struct{}{pointID: {}})
+	response.Hits = trimHits(hits, limit)
+	return response, nil
+}
+
+func withoutHitIDs(hits []SearchHit, excluded map[uint64]struct{}) []SearchHit {
+	filtered := hits[:0]
+	for _, hit := range hits {
+		if isExcluded(excluded, hit.ID) {
+			continue
+		}
+		filtered = append(filtered, hit)
+	}
+	return filtered
+}
@@ -0,0 +1,59 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchByPointIDExcludesSourcePoint(t *testing.T) {
+	t.Parallel()
+
+	var captured map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/collections/demo/points/7":
+			writeJSON(t, writer, map[string]any{
+				"id":      7,
+				"values":  []float32{1, 0},
+				"payload": map[string]any{},
+			})
+		case "/collections/demo/search/topk":
+			if err := json.NewDecoder(request.Body).Decode(&captured); err != nil {
+				t.Fatalf("decode request body: %v", err)
+			}
+			writeJSON(t, writer, map[string]any{
+				"metric": "dot",
+				"mode":   "exact",
+				"hits": []map[string]any{
+					{"id": 7, "value": 1.0},
+					{"id": 3, "value": 0.9},
+					{"id": 4, "value": 0.5},
+				},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", request.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	response, err := client.SearchByPointID(context.Background(), "demo", 7, &SearchTopKOptions{Limit: IntPtr(2)})
+	if err != nil {
+		t.Fatalf("search by point id failed: %v", err)
+	}
+
+	if limit, ok := captured["limit"].(float64); !ok || int(limit) != 3 {
+		t.Fatalf("expected limit=3 to compensate for the source point, got: %#v", captured["limit"])
+	}
+	if len(response.Hits) != 2 {
+		t.Fatalf("unexpected hits: %#v", response.Hits)
+	}
+	for _, hit := range response.Hits {
+		if hit.ID == 7 {
+			t.Fatalf("source point leaked into hits: %#v", response.Hits)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package aionbd
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServerTiming maps the metric names of a Server-Timing response header to
+// their dur parameter. Metrics without a valid dur map to zero.
+type ServerTiming map[string]time.Duration
+
+// WithServerTiming fills target with the Server-Timing metrics of a successful
+// response, e.g. "search;dur=12.3" becomes {"search": 12.3ms}. The header is
+// parsed leniently: malformed entries are skipped and, when a metric repeats,
+// the first occurrence wins. Servers that send no header leave target empty.
+func WithServerTiming(target *ServerTiming) CallOption {
+	return func(call *callConfig) {
+		call.serverTiming = target
+	}
+}
+
+// maxServerTimingMillis keeps durations within time.Duration.
+const maxServerTimingMillis = float64(math.MaxInt64 / int64(time.Millisecond))
+
+func parseServerTiming(values []string) ServerTiming {
+	timing := make(ServerTiming)
+	for _, value := range values {
+		for _, entry := range splitOutsideQuotes(value, ',') {
+			params := splitOutsideQuotes(entry, ';')
+			name := strings.TrimSpace(params[0])
+			if name == "" || strings.ContainsAny(name, "\"= \t") {
+				continue
+			}
+			if _, seen := timing[name]; seen {
+				continue
+			}
+			timing[name] = serverTimingDuration(params[1:])
+		}
+	}
+	return timing
+}
+
+func serverTimingDuration(params []string) time.Duration {
+	for _, param := range params {
+		key, value, _ := strings.Cut(param, "=")
+		if !strings.EqualFold(strings.TrimSpace(key), "dur") {
+			continue
+		}
+		millis, err := strconv.ParseFloat(strings.Trim(strings.TrimSpace(value), `"`), 64)
+		if err != nil || !(millis >= 0 && millis <= maxServerTimingMillis) {
+			return 0
+		}
+		// Only the first dur parameter counts.
+		return time.Duration(millis * float64(time.Millisecond))
+	}
+	return 0
+}
+
+// splitOutsideQuotes splits s at sep, ignoring separators inside quoted
+// strings such as desc="a, b; c".
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	quoted := false
+	for index := 0; index < len(s); index++ {
+		switch {
+		case s[index] == '\\' && quoted:
+			index++
+		case s[index] == '"':
+			quoted = !quoted
+		case s[index] == sep && !quoted:
+			parts = append(parts, s[start:index])
+			start = index + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func (call *callConfig) captureServerTiming(header http.Header) {
+	if call.serverTiming != nil {
+		*call.serverTiming = parseServerTiming(header.Values("Server-Timing"))
+	}
+}
@@ -0,0 +1,49 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithServerTimingParsesDurations(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Server-Timing", "search;dur=12.3")
+		writer.Header().Add("Server-Timing", `decode;desc="parse, validate; etc";dur=0.5, cache, db;dur=oops`)
+		writeJSON(t, writer, map[string]any{"metric": "dot", "mode": "exact", "hits": []map[string]any{}})
+	}))
+	defer server.Close()
+
+	var timing ServerTiming
+	client := NewClient(server.URL, nil)
+	if _, err := client.SearchCollectionTopK(context.Background(), "demo", []float32{1}, nil, WithServerTiming(&timing)); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	want := ServerTiming{
+		"search": 12300 * time.Microsecond,
+		"decode": 500 * time.Microsecond,
+		"cache":  0,
+		"db":     0,
+	}
+	if len(timing) != len(want) {
+		t.Fatalf("expected %v, got %v", want, timing)
+	}
+	for name, duration := range want {
+		if got, found := timing[name]; !found || got != duration {
+			t.Fatalf("%s: expected %v, got %v (found %v)", name, duration, got, found)
+		}
+	}
+}
+
+func TestParseServerTimingIsDefensive(t *testing.T) {
+	t.Parallel()
+
+	timing := parseServerTiming([]string{`, ;dur=3, "bad";dur=1, total;dur=NaN, total;dur=9, edge;dur=1e300, first;dur=1;dur=2`})
+	if len(timing) != 3 || timing["total"] != 0 || timing["edge"] != 0 || timing["first"] != time.Millisecond {
+		t.Fatalf("unexpected timing %v", timing)
+	}
+}
@@ -0,0 +1,26 @@
+package aionbd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+// HMACSigner returns a ClientOptions.Signer that sets header to the hex
+// HMAC-SHA256 of "METHOD\nREQUEST_URI\nhex(sha256(body))", where REQUEST_URI
+// is the escaped path plus query string the server receives.
+func HMACSigner(secret []byte, header string) func(*http.Request, []byte) error {
+	key := append([]byte(nil), secret...)
+	return func(request *http.Request, body []byte) error {
+		request.Header.Set(header, SignatureHMAC(key, request.Method, request.URL.RequestURI(), body))
+		return nil
+	}
+}
+
+func SignatureHMAC(secret []byte, method string, requestURI string, body []byte) string {
+	bodyDigest := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(method + "\n" + requestURI + "\n" + hex.EncodeToString(bodyDigest[:])))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,37 @@
+package aionbd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHMACSignerSetsRecomputableSignature(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("shared-secret")
+	var signature, expected string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			t.Fatalf("read request body: %v", err)
+		}
+		signature = request.Header.Get("X-Signature")
+		expected = SignatureHMAC(secret, request.Method, request.URL.RequestURI(), body)
+		writeJSON(t, writer, map[string]any{"id": 7, "created": true})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{Signer: HMACSigner(secret, "X-Signature")})
+	if _, err := client.UpsertPoint(context.Background(), "demo", 7, []float32{1, 2}, PointPayload{"k": "v"}); err != nil {
+		t.Fatalf("upsert failed: %v", err)
+	}
+	if signature == "" {
+		t.Fatal("expected signature header")
+	}
+	if signature != expected {
+		t.Fatalf("signature mismatch: got %s want %s", signature, expected)
+	}
+}
@@ -0,0 +1,75 @@
+package aionbd
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// SimpleRequests shapes requests as CORS "simple requests" where possible, so
+// a browser (js/wasm build) skips the OPTIONS preflight that AIONBD does not
+// answer. Tradeoffs:
+//   - the API key and bearer token travel as the api_key and access_token
+//     query parameters, where proxies and access logs can record them, and
+//     only work if the server or a proxy in front of it accepts them there;
+//   - JSON bodies are labeled text/plain, which the server must accept;
+//   - every header outside the CORS safelist is dropped: default, context, and
+//     per-call headers, Idempotency-Key, If-None-Match, X-Consistent-Read;
+//   - request compression is disabled.
+// PUT and DELETE calls still need a preflight.
+
+const (
+	simpleAPIKeyParam      = "api_key"
+	simpleBearerTokenParam = "access_token"
+	simpleContentType      = "text/plain;charset=UTF-8"
+)
+
+var corsSafelistedHeaders = map[string]bool{
+	"Accept":           true,
+	"Accept-Language":  true,
+	"Content-Language": true,
+	"Content-Type":     true,
+}
+
+func (c *Client) simplifyRequest(request *http.Request) {
+	if c.apiKey != "" || c.bearerToken != "" {
+		query := request.URL.Query()
+		if c.apiKey != "" {
+			query.Set(simpleAPIKeyParam, c.apiKey)
+		}
+		if c.bearerToken != "" {
+			query.Set(simpleBearerTokenParam, c.bearerToken)
+		}
+		request.URL.RawQuery = query.Encode()
+	}
+	for key := range request.Header {
+		if !corsSafelistedHeaders[key] {
+			delete(request.Header, key)
+		}
+	}
+	if request.Header.Get("Content-Type") != "" {
+		request.Header.Set("Content-Type", simpleContentType)
+	}
+}
+
+// redactQueryCredentials keeps credentials moved into the query string out of
+// transport error messages, which include the request URL.
+func redactQueryCredentials(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	parsed, parseErr := url.Parse(urlErr.URL)
+	if parseErr != nil {
+		return err
+	}
+	query := parsed.Query()
+	for _, param := range []string{simpleAPIKeyParam, simpleBearerTokenParam} {
+		if query.Has(param) {
+			query.Set(param, "REDACTED")
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	urlErr.URL = parsed.String()
+	return err
+}
@@ -0,0 +1,76 @@
+//go:build js && wasm
+
+package aionbd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// roundTripFunc stands in for the browser fetch transport, which cannot reach
+// an httptest server from the wasm runtime.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func TestSimpleRequestsAreSafelistedUnderWasm(t *testing.T) {
+	var mu sync.Mutex
+	var requests []*http.Request
+	transport := roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+		body := `{"created":1,"updated":0,"metric":"dot","mode":"exact","hits":[]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})
+
+	client := NewClient("http://aionbd.test", &ClientOptions{
+		APIKey:         "secret",
+		BearerToken:    "token",
+		Headers:        map[string]string{"X-Tenant": "a"},
+		Compression:    CompressionGzip,
+		SimpleRequests: true,
+		HTTPClient:     &http.Client{Transport: transport},
+	})
+	ctx := WithContextHeaders(context.Background(), map[string]string{"X-Trace": "1"})
+	if _, err := client.UpsertPointsBatch(ctx, "demo", []UpsertPointsBatchItem{{ID: 1, Values: []float32{1}}}); err != nil {
+		t.Fatalf("UpsertPointsBatch returned error: %v", err)
+	}
+	if _, err := client.SearchCollectionTopK(ctx, "other", []float32{1}, nil, WithHeader("X-Call", "1")); err != nil {
+		t.Fatalf("SearchCollectionTopK returned error: %v", err)
+	}
+
+	// Only CORS-safelisted request headers may reach fetch, or the browser
+	// sends a preflight.
+	allowed := map[string]bool{"Accept": true, "Content-Type": true}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	for _, request := range requests {
+		for key := range request.Header {
+			if !allowed[key] {
+				t.Errorf("unexpected header %s on %s", key, request.URL.Path)
+			}
+		}
+		if request.Body != nil && request.Header.Get("Content-Type") != simpleContentType {
+			t.Errorf("expected a text/plain body, got %q", request.Header.Get("Content-Type"))
+		}
+		query := request.URL.Query()
+		if query.Get("api_key") != "secret" || query.Get("access_token") != "token" {
+			t.Errorf("expected credentials in the query, got %q", request.URL.RawQuery)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSimpleRequestsSendOnlySafelistedHeaders(t *testing.T) {
+	t.Parallel()
+
+	// Accept-Encoding and User-Agent are set by the transport (or the browser)
+	// rather than the SDK.
+	allowed := map[string]bool{"Accept": true, "Accept-Encoding": true, "User-Agent": true, "Content-Type": true, "Content-Length": true}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		for key := range request.Header {
+			if !allowed[key] {
+				t.Errorf("unexpected header %s on %s", key, request.URL.Path)
+			}
+		}
+		if request.ContentLength > 0 && request.Header.Get("Content-Type") != simpleContentType {
+			t.Errorf("expected a text/plain body, got %q", request.Header.Get("Content-Type"))
+		}
+		query := request.URL.Query()
+		if query.Get("api_key") != "secret" || query.Get("access_token") != "token" {
+			t.Errorf("expected credentials in the query, got %q", request.URL.RawQuery)
+		}
+		writeJSON(t, writer, map[string]any{"created": 1, "updated": 0, "metric": "dot", "mode": "exact", "hits": []map[string]any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{
+		APIKey:         "secret",
+		BearerToken:    "token",
+		Headers:        map[string]string{"X-Tenant": "a"},
+		Compression:    CompressionGzip,
+		SimpleRequests: true,
+	})
+	ctx := WithContextHeaders(context.Background(), map[string]string{"X-Trace": "1"})
+	if _, err := client.UpsertPointsBatch(ctx, "demo", []UpsertPointsBatchItem{{ID: 1, Values: []float32{1}}}); err != nil {
+		t.Fatalf("UpsertPointsBatch returned error: %v", err)
+	}
+	options := &SearchTopKOptions{SearchOptions: SearchOptions{ConsistentRead: true}}
+	if _, err := client.SearchCollectionTopK(ctx, "other", []float32{1}, options, WithHeader("X-Call", "1")); err != nil {
+		t.Fatalf("SearchCollectionTopK returned error: %v", err)
+	}
+}
+
+func TestSimpleRequestsRedactQueryCredentialsInErrors(t *testing.T) {
+	t.Parallel()
+
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+
+	client := NewClient(dead.URL, &ClientOptions{APIKey: "secret", SimpleRequests: true})
+	_, err := client.Live(context.Background())
+	if err == nil || strings.Contains(err.Error(), "secret") {
+		t.Fatalf("expected a redacted transport error, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrStaleCursor reports that the pooled node pinned by a session stopped
+// accepting connections, so cursors and tokens it issued cannot be resumed
+// elsewhere.
+var ErrStaleCursor = errors.New("session node is unreachable; cursor is stale")
+
+type sessionKey struct{}
+
+type session struct {
+	mu   sync.Mutex
+	node string
+}
+
+// WithSession returns a context whose requests on a pooled client all go to
+// the node that served the first of them. If that node later refuses a
+// connection, requests fail with ErrStaleCursor instead of switching nodes.
+// IteratePoints and ConsistentRead searches start a session on their own; an
+// existing session in ctx is reused. Clients without a pool ignore sessions.
+func WithSession(ctx context.Context) context.Context {
+	if sessionFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, sessionKey{}, &session{})
+}
+
+func sessionFrom(ctx context.Context) *session {
+	current, _ := ctx.Value(sessionKey{}).(*session)
+	return current
+}
+
+func (s *session) pinned() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.node
+}
+
+// pin records node unless a concurrent first request already pinned one.
+func (s *session) pin(node string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.node == "" {
+		s.node = node
+	}
+}
+
+func (c *Client) sendRequestInSession(ctx context.Context, current *session, method string, path string, encoded []byte, contentEncoding string, hasBody bool, raw bool, call *callConfig) ([]byte, error) {
+	node := current.pinned()
+	if node == "" {
+		payload, served, err := c.sendRequestFailover(ctx, method, path, encoded, contentEncoding, hasBody, raw, call)
+		if served != "" {
+			current.pin(served)
+		}
+		return payload, err
+	}
+	payload, err := c.sendRequest(ctx, node, method, path, encoded, contentEncoding, hasBody, raw, call)
+	if isDialError(err) {
+		c.pool.markDown(node, time.Now())
+		return nil, &Error{Method: method, Path: path, Err: fmt.Errorf("%w: node %s: %w", ErrStaleCursor, node, errors.Unwrap(err))}
+	}
+	return payload, err
+}
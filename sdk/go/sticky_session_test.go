@@ -0,0 +1,70 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestPooledIteratorSticksToOneNodeAndReportsStaleCursor(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	served := map[string]int{}
+	newNode := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			mu.Lock()
+			served[name]++
+			mu.Unlock()
+			page := 0
+			if token := request.URL.Query().Get("page_token"); token != "" {
+				page, _ = strconv.Atoi(token)
+			}
+			response := map[string]any{"points": []map[string]any{{"id": page}}, "total": 3}
+			if page < 2 {
+				response["next_token"] = strconv.Itoa(page + 1)
+			}
+			writeJSON(t, writer, response)
+		}))
+	}
+	nodeA, nodeB := newNode("a"), newNode("b")
+	defer nodeA.Close()
+	defer nodeB.Close()
+
+	client := NewPooledClient([]string{nodeA.URL, nodeB.URL}, nil)
+	iterator := client.IteratePoints(context.Background(), "demo", nil)
+	count := 0
+	for iterator.Next() {
+		count++
+	}
+	if err := iterator.Err(); err != nil || count != 3 {
+		t.Fatalf("expected 3 points without error, got %d, %v", count, err)
+	}
+	mu.Lock()
+	if len(served) != 1 {
+		t.Fatalf("expected every page from one node, got %v", served)
+	}
+	mu.Unlock()
+
+	// The next iteration starts on the other node; kill it after one page.
+	iterator = client.IteratePoints(context.Background(), "demo", nil)
+	if !iterator.Next() {
+		t.Fatalf("expected a first point, got %v", iterator.Err())
+	}
+	mu.Lock()
+	pinned := nodeA
+	if served["b"] > 0 {
+		pinned = nodeB
+	}
+	mu.Unlock()
+	pinned.Close()
+	for iterator.Next() {
+	}
+	if err := iterator.Err(); !errors.Is(err, ErrStaleCursor) {
+		t.Fatalf("expected ErrStaleCursor, got %v", err)
+	}
+}
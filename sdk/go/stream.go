@@ -0,0 +1,55 @@
+package aionbd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// openStream sends a request whose response body is consumed incrementally
+// by the caller, who must close it. Unlike sendRequest it neither buffers the
+// response nor retries; the transport undoes gzip transfer compression.
+func (c *Client) openStream(ctx context.Context, method string, path string, encoded []byte, accept string, call *callConfig) (io.ReadCloser, error) {
+	if err := c.rateLimiter.wait(ctx, path); err != nil {
+		return nil, &Error{Method: method, Path: path, Err: err}
+	}
+	var requestBody io.Reader
+	if encoded != nil {
+		requestBody = bytes.NewReader(encoded)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, c.nodes(call)[0]+path, requestBody)
+	if err != nil {
+		return nil, &Error{Method: method, Path: path, Err: err}
+	}
+	request.Header.Set("Accept", accept)
+	c.applyRequestHeaders(ctx, request, call)
+	if encoded != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if c.simpleRequests {
+		c.simplifyRequest(request)
+	}
+	if c.signer != nil {
+		if err := c.signer(request, encoded); err != nil {
+			return nil, &Error{Method: method, Path: path, Err: fmt.Errorf("sign request: %w", err)}
+		}
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		if c.simpleRequests {
+			err = redactQueryCredentials(err)
+		}
+		return nil, &Error{Method: method, Path: path, Err: err}
+	}
+	observeOperationID(ctx, response.Header)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		defer response.Body.Close()
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 64<<10))
+		return nil, &Error{Status: response.StatusCode, Method: method, Path: path, Body: string(responseBody)}
+	}
+	call.captureHeaders(response.Header)
+	return response.Body, nil
+}
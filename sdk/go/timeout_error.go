@@ -0,0 +1,27 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// ErrTimeout matches, via errors.Is, any *Error whose IsTimeout reports true.
+var ErrTimeout = errors.New("request timed out")
+
+// IsTimeout reports whether the request was cut short by its deadline, a
+// network timeout (including ClientOptions.Timeout), or context cancellation.
+func (e *Error) IsTimeout() bool {
+	if e == nil || e.Err == nil {
+		return false
+	}
+	if errors.Is(e.Err, context.DeadlineExceeded) || errors.Is(e.Err, context.Canceled) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(e.Err, &netErr) && netErr.Timeout()
+}
+
+func (e *Error) Is(target error) bool {
+	return target == ErrTimeout && e.IsTimeout()
+}
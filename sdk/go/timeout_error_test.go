@@ -0,0 +1,43 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimedOutRequestsReportIsTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/live" {
+			<-release
+		}
+		http.Error(writer, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, &ClientOptions{Timeout: 20 * time.Millisecond})
+	_, err := client.Live(context.Background())
+	var requestErr *Error
+	if !errors.As(err, &requestErr) || !requestErr.IsTimeout() || !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected a client timeout to report IsTimeout, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = NewClient(server.URL, nil).Live(ctx)
+	if !errors.As(err, &requestErr) || !requestErr.IsTimeout() || !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected a context deadline to report IsTimeout, got %v", err)
+	}
+
+	_, err = client.Ready(context.Background())
+	if !errors.As(err, &requestErr) || requestErr.IsTimeout() || errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected an HTTP 500 not to be a timeout, got %v", err)
+	}
+}
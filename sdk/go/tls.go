@@ -0,0 +1,58 @@
+package aionbd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+func clientTLSConfig(opts ClientOptions) (*tls.Config, error) {
+	if opts.TLSConfig == nil && opts.ClientCertFile == "" && opts.ClientCertKeyFile == "" &&
+		opts.RootCAFile == "" && opts.RootCAs == nil && !opts.InsecureSkipVerify {
+		return nil, nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if opts.TLSConfig != nil {
+		config = opts.TLSConfig.Clone()
+	}
+	if opts.ClientCertFile != "" || opts.ClientCertKeyFile != "" {
+		if opts.ClientCertFile == "" || opts.ClientCertKeyFile == "" {
+			return nil, fmt.Errorf("ClientCertFile and ClientCertKeyFile must be set together")
+		}
+		certificate, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientCertKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		config.Certificates = append(config.Certificates, certificate)
+	}
+	if opts.RootCAs != nil {
+		config.RootCAs = opts.RootCAs
+	}
+	if opts.RootCAFile != "" {
+		pool, err := loadRootCAFile(opts.RootCAFile, config.RootCAs)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if opts.InsecureSkipVerify {
+		config.InsecureSkipVerify = true
+	}
+	return config, nil
+}
+
+func loadRootCAFile(path string, base *x509.CertPool) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read root CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if base != nil {
+		pool = base.Clone()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("root CA file %s contains no valid PEM certificates", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,176 @@
+package aionbd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "aionbd-sdk-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	return certificate, certFile, keyFile
+}
+
+func TestClientCertificateIsPresentedToMutualTLSServer(t *testing.T) {
+	t.Parallel()
+
+	certificate, certFile, keyFile := writeClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(certificate)
+
+	var presented string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if len(request.TLS.PeerCertificates) > 0 {
+			presented = request.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 1})
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCAs := x509.NewCertPool()
+	serverCAs.AddCert(server.Certificate())
+	client := NewClient(server.URL, &ClientOptions{
+		TLSConfig:         &tls.Config{RootCAs: serverCAs},
+		ClientCertFile:    certFile,
+		ClientCertKeyFile: keyFile,
+	})
+	if err := client.ConfigError(); err != nil {
+		t.Fatalf("unexpected config error: %v", err)
+	}
+	if _, err := client.Live(context.Background()); err != nil {
+		t.Fatalf("live over mTLS failed: %v", err)
+	}
+	if presented != "aionbd-sdk-test" {
+		t.Fatalf("client certificate was not presented, got: %q", presented)
+	}
+}
+
+func TestInvalidClientCertificateFailsRequests(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("https://127.0.0.1:1", &ClientOptions{
+		ClientCertFile:    filepath.Join(t.TempDir(), "missing.pem"),
+		ClientCertKeyFile: filepath.Join(t.TempDir(), "missing-key.pem"),
+	})
+	if client.ConfigError() == nil {
+		t.Fatal("expected config error for missing certificate files")
+	}
+	_, err := client.Live(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "load client certificate") {
+		t.Fatalf("expected certificate load error, got: %v", err)
+	}
+}
+
+func TestRootCAFileTrustsSelfSignedServer(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 1})
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("write CA file: %v", err)
+	}
+
+	untrusted := NewClient(server.URL, nil)
+	if _, err := untrusted.Live(context.Background()); err == nil {
+		t.Fatal("expected certificate verification failure without a custom CA")
+	}
+
+	trusted := NewClient(server.URL, &ClientOptions{RootCAFile: caFile})
+	if _, err := trusted.Live(context.Background()); err != nil {
+		t.Fatalf("live with custom CA failed: %v", err)
+	}
+}
+
+func TestInvalidRootCAFileFailsRequests(t *testing.T) {
+	t.Parallel()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("write CA file: %v", err)
+	}
+	client := NewClient("https://127.0.0.1:1", &ClientOptions{RootCAFile: caFile})
+	_, err := client.Live(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no valid PEM certificates") {
+		t.Fatalf("expected invalid CA file error, got: %v", err)
+	}
+}
+
+func TestInsecureSkipVerifyWarnsAndConnects(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 1})
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(server.URL, &ClientOptions{
+		InsecureSkipVerify: true,
+		Logger:             slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "verification is disabled") {
+		t.Fatalf("expected insecure TLS warning, got: %q", logs.String())
+	}
+	if _, err := client.Live(context.Background()); err != nil {
+		t.Fatalf("live against self-signed server failed: %v", err)
+	}
+}
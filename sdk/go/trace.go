@@ -0,0 +1,74 @@
+package aionbd
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+type HTTPTraceTimings struct {
+	Method               string
+	Path                 string
+	DNSLookup            time.Duration
+	Connect              time.Duration
+	TLSHandshake         time.Duration
+	GotFirstResponseByte time.Duration
+	ConnReused           bool
+}
+
+type httpTraceRecorder struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	timings      HTTPTraceTimings
+}
+
+func withHTTPTrace(ctx context.Context, method string, path string) (context.Context, *httpTraceRecorder) {
+	recorder := &httpTraceRecorder{
+		start:   time.Now(),
+		timings: HTTPTraceTimings{Method: method, Path: path},
+	}
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			recorder.mark(func() { recorder.dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			recorder.mark(func() { recorder.timings.DNSLookup = time.Since(recorder.dnsStart) })
+		},
+		ConnectStart: func(string, string) {
+			recorder.mark(func() { recorder.connectStart = time.Now() })
+		},
+		ConnectDone: func(string, string, error) {
+			recorder.mark(func() { recorder.timings.Connect = time.Since(recorder.connectStart) })
+		},
+		TLSHandshakeStart: func() {
+			recorder.mark(func() { recorder.tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			recorder.mark(func() { recorder.timings.TLSHandshake = time.Since(recorder.tlsStart) })
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			recorder.mark(func() { recorder.timings.ConnReused = info.Reused })
+		},
+		GotFirstResponseByte: func() {
+			recorder.mark(func() { recorder.timings.GotFirstResponseByte = time.Since(recorder.start) })
+		},
+	}
+	return httptrace.WithClientTrace(ctx, trace), recorder
+}
+
+func (recorder *httpTraceRecorder) mark(update func()) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	update()
+}
+
+func (recorder *httpTraceRecorder) snapshot() HTTPTraceTimings {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return recorder.timings
+}
@@ -0,0 +1,40 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPTraceReportsFirstResponseByte(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 1})
+	}))
+	defer server.Close()
+
+	var timings []HTTPTraceTimings
+	client := NewClient(server.URL, &ClientOptions{
+		EnableHTTPTrace: true,
+		OnHTTPTrace: func(recorded HTTPTraceTimings) {
+			timings = append(timings, recorded)
+		},
+	})
+	if _, err := client.Live(context.Background()); err != nil {
+		t.Fatalf("live failed: %v", err)
+	}
+
+	if len(timings) != 1 {
+		t.Fatalf("expected one trace callback, got %d", len(timings))
+	}
+	if timings[0].GotFirstResponseByte <= 0 {
+		t.Fatalf("expected non-zero time to first byte: %#v", timings[0])
+	}
+	if timings[0].Method != http.MethodGet || timings[0].Path != "/live" {
+		t.Fatalf("unexpected trace target: %#v", timings[0])
+	}
+}
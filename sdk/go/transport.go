@@ -0,0 +1,58 @@
+package aionbd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDialTimeout = 30 * time.Second
+
+	unixSocketScheme = "unix://"
+	// unixSocketBaseURL is the HTTP-level base URL used for unix socket
+	// clients; the host is never dialed.
+	unixSocketBaseURL = "http://localhost"
+)
+
+// unixSocketPath returns the socket path of a unix:///path/to.sock base URL.
+func unixSocketPath(baseURL string) (string, bool) {
+	if !strings.HasPrefix(baseURL, unixSocketScheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(baseURL, unixSocketScheme), true
+}
+
+func newTransport(opts ClientOptions, socketPath string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	dialTimeout := opts.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = defaultDialTimeout
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second}
+	transport.DialContext = dialer.DialContext
+	if socketPath != "" {
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socketPath)
+		}
+	}
+
+	if opts.ResponseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+	}
+	if opts.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	}
+
+	tlsConfig, err := clientTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport, nil
+}
@@ -0,0 +1,76 @@
+package aionbd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResponseHeaderTimeoutAppliesToSDKTransport(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-release:
+		case <-time.After(2 * time.Second):
+		}
+		writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 1})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, &ClientOptions{
+		Timeout:               5 * time.Second,
+		ResponseHeaderTimeout: 50 * time.Millisecond,
+	})
+	started := time.Now()
+	_, err := client.Live(context.Background())
+	if err == nil {
+		t.Fatal("expected response header timeout")
+	}
+	if !strings.Contains(err.Error(), "timeout awaiting response headers") {
+		t.Fatalf("expected response header timeout, got: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("header timeout fired too late: %s", elapsed)
+	}
+}
+
+func TestUnixSocketBaseURLDialsSocket(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "aionbd")
+	if err != nil {
+		t.Fatalf("create socket dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "aionbd.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("listen on unix socket: %v", err)
+	}
+
+	var host string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		host = request.Host
+		writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 1})
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	client := NewClient("unix://"+socketPath, nil)
+	if _, err := client.Live(context.Background()); err != nil {
+		t.Fatalf("live over unix socket failed: %v", err)
+	}
+	if host != "localhost" {
+		t.Fatalf("unexpected host header: %q", host)
+	}
+}
@@ -1,6 +1,9 @@
 package aionbd
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -45,15 +48,18 @@ type ReadyResponse struct {
 }
 
 type DistanceResponse struct {
-	Metric Metric  `json:"metric"`
-	Value  float32 `json:"value"`
+	Metric Metric    `json:"metric"`
+	Value  float32   `json:"value"`
+	Left   []float32 `json:"left,omitempty"`
+	Right  []float32 `json:"right,omitempty"`
 }
 
 type CollectionResponse struct {
-	Name         string `json:"name"`
-	Dimension    int    `json:"dimension"`
-	StrictFinite bool   `json:"strict_finite"`
-	PointCount   int    `json:"point_count"`
+	Name          string `json:"name"`
+	Dimension     int    `json:"dimension"`
+	StrictFinite  bool   `json:"strict_finite"`
+	PointCount    int    `json:"point_count"`
+	DefaultMetric Metric `json:"default_metric,omitempty"`
 }
 
 type ListCollectionsResponse struct {
@@ -94,8 +100,10 @@ type SearchTopKBatchResponse struct {
 }
 
 type UpsertPointResponse struct {
-	ID      uint64 `json:"id"`
-	Created bool   `json:"created"`
+	ID       uint64         `json:"id"`
+	Created  bool           `json:"created"`
+	Error    string         `json:"error,omitempty"`
+	Previous *PointResponse `json:"previous,omitempty"`
 }
 
 type UpsertPointsBatchItem struct {
@@ -108,16 +116,20 @@ type UpsertPointsBatchResponse struct {
 	Created int                   `json:"created"`
 	Updated int                   `json:"updated"`
 	Results []UpsertPointResponse `json:"results"`
+	// Splits counts how often the client halved the batch after a 413.
+	Splits int `json:"-"`
 }
 
 type PointResponse struct {
-	ID      uint64       `json:"id"`
-	Values  []float32    `json:"values"`
-	Payload PointPayload `json:"payload"`
+	ID        uint64       `json:"id"`
+	Values    []float32    `json:"values"`
+	ValuesF16 []uint16     `json:"-"`
+	Payload   PointPayload `json:"payload"`
 }
 
 type PointIDResponse struct {
-	ID uint64 `json:"id"`
+	ID      uint64       `json:"id"`
+	Payload PointPayload `json:"payload,omitempty"`
 }
 
 type ListPointsResponse struct {
@@ -125,6 +137,7 @@ type ListPointsResponse struct {
 	Total       int               `json:"total"`
 	NextOffset  *int              `json:"next_offset"`
 	NextAfterID *uint64           `json:"next_after_id"`
+	NextToken   *string           `json:"next_token,omitempty"`
 }
 
 type DeletePointResponse struct {
@@ -203,11 +216,20 @@ type MetricsResponse struct {
 }
 
 type SearchOptions struct {
+	// Metric falls back to ClientOptions.DefaultMetric, then to a default
+	// metric this process recorded for the collection under the same
+	// credentials and headers (CreateCollectionWithOptions), then to the
+	// server default. Recorded metrics are process-local: a fresh client
+	// does not know them until it creates the collection or sees the metric
+	// reported by GetCollection.
 	Metric         Metric
 	Mode           SearchMode
 	TargetRecall   *float32
 	Filter         map[string]any
 	IncludePayload *bool
+	ExcludeIDs     []uint64
+	RoundScoresTo  *int
+	ConsistentRead bool
 }
 
 type SearchTopKOptions struct {
@@ -216,9 +238,32 @@ type SearchTopKOptions struct {
 }
 
 type ListPointsOptions struct {
-	Offset  int
-	Limit   *int
-	AfterID *uint64
+	Offset         int
+	Limit          *int
+	AfterID        *uint64
+	PageToken      *string
+	IncludePayload bool
+	Fields         []string
+}
+
+type DistanceOptions struct {
+	// EchoInputs asks the server to return the vectors it scored (after any
+	// normalization) in DistanceResponse.Left and Right.
+	EchoInputs bool
+}
+
+type UpsertPointOptions struct {
+	OnlyIfAbsent   bool
+	ReturnPrevious bool
+}
+
+type GetPointOptions struct {
+	Float16 bool
+}
+
+type UpsertPointsBatchOptions struct {
+	ReturnResults *bool
+	FailuresOnly  bool
 }
 
 type ClientOptions struct {
@@ -227,6 +272,101 @@ type ClientOptions struct {
 	APIKey      string
 	BearerToken string
 	Headers     map[string]string
+	// MultiHeaders are added to every request with Header.Add, so a key can
+	// carry several values. They are added after Headers, whose value for the
+	// same key comes first.
+	MultiHeaders http.Header
+
+	// DefaultMetric replaces MetricDot for calls that leave the metric empty.
+	DefaultMetric Metric
+	// DefaultMode replaces SearchModeAuto for calls that leave the mode empty.
+	DefaultMode SearchMode
+	// AdaptiveExactThreshold is the point count below which SearchModeAdaptive
+	// picks exact search (default 10000).
+	AdaptiveExactThreshold int
+	// IncludePayloadByDefault sets include_payload for searches whose
+	// SearchOptions.IncludePayload is nil. Nil leaves the server default (true).
+	IncludePayloadByDefault *bool
+
+	DialTimeout           time.Duration
+	ResponseHeaderTimeout time.Duration
+	TLSHandshakeTimeout   time.Duration
+
+	TLSConfig         *tls.Config
+	ClientCertFile    string
+	ClientCertKeyFile string
+	RootCAFile        string
+	RootCAs           *x509.CertPool
+
+	// InsecureSkipVerify disables server certificate checks on the SDK-built
+	// transport. Intended for local development only; a warning is logged
+	// every time a client is constructed with it.
+	InsecureSkipVerify bool
+	Logger             *slog.Logger
+	// LogBodies logs every request body through Logger at Info level, with
+	// vectors replaced by "[<dim> floats]" and any field named in
+	// RedactPayloadKeys replaced by "***".
+	LogBodies         bool
+	RedactPayloadKeys []string
+
+	Signer        func(request *http.Request, body []byte) error
+	CanonicalJSON bool
+	// Compression gzips or zstd-compresses request bodies when that saves at
+	// least 10%. The server (or a proxy in front of it) must accept
+	// Content-Encoding; zstd falls back to plain bodies after a 415.
+	Compression CompressionAlgorithm
+	// AllowNonJSONContentType skips the Content-Type check on JSON endpoints,
+	// for servers that label JSON bodies with another media type.
+	AllowNonJSONContentType bool
+
+	// IDGenerator assigns point IDs for UpsertPointAuto, e.g.
+	// FNVPayloadIDGenerator.
+	IDGenerator func(values []float32, payload PointPayload) uint64
+
+	Ingest             IngestConfig
+	RejectDuplicateIDs bool
+	RateLimit          *RateLimit
+	RetryPolicy        *RetryPolicy
+	SearchCache        *SearchCacheConfig
+	PointCache         *PointCacheConfig
+	MetadataCacheTTL   time.Duration
+	OnRetry            func(attempt int, info RetryInfo)
+	// CoalesceSearches lets concurrent identical SearchCollectionTopK calls
+	// share one request.
+	CoalesceSearches bool
+	// SimpleRequests avoids CORS preflights in browsers: credentials move to
+	// query parameters, bodies are sent as text/plain, compression is off,
+	// and headers outside the CORS safelist are dropped.
+	SimpleRequests bool
+	// OperationTimeout bounds a whole ImportCollection, ExportCollection,
+	// UpsertPointsChunked, or CopyPoints call, separately from the
+	// per-request Timeout.
+	// Errors caused by it wrap ErrOperationTimeout. Zero means no bound.
+	OperationTimeout time.Duration
+
+	// MaxBatchItems caps the points of one batch upsert and the queries of one
+	// batch search. Larger batches fail with ErrBatchTooLarge before any
+	// request is sent, unless AutoChunkBatches splits them into sequential
+	// requests of at most MaxBatchItems each. Zero means no limit.
+	// AutoChunkBatches also halves a batch upsert the server rejects with 413
+	// (ErrPayloadTooLarge), recursively, until the halves are accepted.
+	MaxBatchItems    int
+	AutoChunkBatches bool
+
+	// AutoCreateCollections creates a missing collection on the first upsert,
+	// using the vector length as dimension, then retries the upsert once.
+	// Intended for prototyping.
+	AutoCreateCollections  bool
+	AutoCreateStrictFinite bool
+
+	EnableHTTPTrace bool
+	OnHTTPTrace     func(HTTPTraceTimings)
+
+	// OnClockSkew is called when a response Date header differs from local
+	// time by more than ClockSkewThreshold (default 30s). Positive skew means
+	// the server clock is ahead. Missing or malformed headers are ignored.
+	OnClockSkew        func(skew time.Duration)
+	ClockSkewThreshold time.Duration
 }
 
 func IntPtr(value int) *int {
@@ -0,0 +1,95 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type FailedUpsertItem struct {
+	Item UpsertPointsBatchItem
+	Err  error
+}
+
+type ChunkedUpsertResponse struct {
+	Created int
+	Updated int
+	Chunks  int
+	Failed  []FailedUpsertItem
+}
+
+// UpsertPointsChunked upserts points in batches of chunkSize. By default the
+// first failed chunk aborts the call. With ClientOptions.Ingest.RetryFailedItems
+// a chunk rejected as invalid (400, 413, or 422) is split in halves and
+// retried until the failing items are isolated; those are reported in Failed
+// and the call succeeds. Other errors, such as 401, 429, 5xx, or transport
+// failures, still abort, since every retry would fail alike.
+func (c *Client) UpsertPointsChunked(ctx context.Context, collection string, points []UpsertPointsBatchItem, chunkSize int, opts ...CallOption) (response ChunkedUpsertResponse, err error) {
+	if chunkSize <= 0 {
+		return ChunkedUpsertResponse{}, fmt.Errorf("chunkSize must be a positive integer")
+	}
+	ctx, finish := c.withOperationTimeout(ctx)
+	defer func() { err = finish(err) }()
+	return c.upsertPointsChunked(ctx, collection, points, chunkSize, opts)
+}
+
+func (c *Client) upsertPointsChunked(ctx context.Context, collection string, points []UpsertPointsBatchItem, chunkSize int, opts []CallOption) (ChunkedUpsertResponse, error) {
+	ctx = withServerThrottle(ctx)
+	var response ChunkedUpsertResponse
+	for start := 0; start < len(points); start += chunkSize {
+		end := min(start+chunkSize, len(points))
+		key := chunkIdempotencyKey(ctx, response.Chunks)
+		response.Chunks++
+		if err := c.upsertChunk(ctx, collection, points[start:end], key, &response, opts); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+// upsertChunk sends chunk under idempotency key (if any); bisected halves get
+// the key suffixed with -0 and -1.
+func (c *Client) upsertChunk(ctx context.Context, collection string, chunk []UpsertPointsBatchItem, key string, response *ChunkedUpsertResponse, opts []CallOption) error {
+	result, err := c.UpsertPointsBatch(ctx, collection, chunk, withIdempotencyKey(opts, key)...)
+	if err == nil {
+		response.Created += result.Created
+		response.Updated += result.Updated
+		return nil
+	}
+	if !c.ingest.RetryFailedItems || !isItemRejection(err) {
+		return err
+	}
+	if len(chunk) == 1 {
+		response.Failed = append(response.Failed, FailedUpsertItem{Item: chunk[0], Err: err})
+		return nil
+	}
+
+	middle := len(chunk) / 2
+	if err := c.upsertChunk(ctx, collection, chunk[:middle], halfKey(key, 0), response, opts); err != nil {
+		return err
+	}
+	return c.upsertChunk(ctx, collection, chunk[middle:], halfKey(key, 1), response, opts)
+}
+
+func halfKey(key string, half int) string {
+	if key == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", key, half)
+}
+
+// isItemRejection reports whether err is the server refusing the items
+// themselves (400, 413, 422), as opposed to auth, rate-limit, server, or
+// transport failures that would affect every retry alike.
+func isItemRejection(err error) bool {
+	var requestErr *Error
+	if !errors.As(err, &requestErr) {
+		return false
+	}
+	switch requestErr.Status {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return true
+	}
+	return false
+}
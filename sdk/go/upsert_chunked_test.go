@@ -0,0 +1,98 @@
+package aionbd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newRejectingBatchServer(t *testing.T, rejectedID uint64) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body struct {
+			Points []UpsertPointsBatchItem `json:"points"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		for _, point := range body.Points {
+			if point.ID == rejectedID {
+				writer.WriteHeader(http.StatusBadRequest)
+				writeJSON(t, writer, map[string]any{"error": "non-finite value"})
+				return
+			}
+		}
+		writeJSON(t, writer, map[string]any{"created": len(body.Points), "updated": 0, "results": []any{}})
+	}))
+}
+
+func chunkedFixture(count int) []UpsertPointsBatchItem {
+	points := make([]UpsertPointsBatchItem, count)
+	for index := range points {
+		points[index] = UpsertPointsBatchItem{ID: uint64(index + 1), Values: []float32{float32(index), 1}}
+	}
+	return points
+}
+
+func TestUpsertPointsChunkedIsolatesRejectedItem(t *testing.T) {
+	t.Parallel()
+
+	server := newRejectingBatchServer(t, 6)
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{Ingest: IngestConfig{RetryFailedItems: true}})
+	response, err := client.UpsertPointsChunked(context.Background(), "demo", chunkedFixture(10), 4)
+	if err != nil {
+		t.Fatalf("chunked upsert failed: %v", err)
+	}
+	if response.Chunks != 3 || response.Created != 9 {
+		t.Fatalf("unexpected chunked upsert response: %#v", response)
+	}
+	if len(response.Failed) != 1 || response.Failed[0].Item.ID != 6 {
+		t.Fatalf("expected only point 6 to fail, got: %#v", response.Failed)
+	}
+	if status := response.Failed[0].Err.(*Error).Status; status != http.StatusBadRequest {
+		t.Fatalf("unexpected failure status: %d", status)
+	}
+}
+
+func TestUpsertPointsChunkedAbortsWithoutRetry(t *testing.T) {
+	t.Parallel()
+
+	server := newRejectingBatchServer(t, 6)
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	response, err := client.UpsertPointsChunked(context.Background(), "demo", chunkedFixture(10), 4)
+	if err == nil {
+		t.Fatal("expected chunk failure to abort")
+	}
+	if response.Chunks != 2 || response.Created != 4 {
+		t.Fatalf("unexpected partial response: %#v", response)
+	}
+}
+
+func TestUpsertPointsChunkedDoesNotBisectServerFailures(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		writer.WriteHeader(http.StatusUnauthorized)
+		writeJSON(t, writer, map[string]any{"error": "invalid api key"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{Ingest: IngestConfig{RetryFailedItems: true}})
+	response, err := client.UpsertPointsChunked(context.Background(), "demo", chunkedFixture(8), 4)
+	if !isStatus(err, http.StatusUnauthorized) {
+		t.Fatalf("expected the 401 to abort the call, got %v", err)
+	}
+	if len(response.Failed) != 0 || requests.Load() != 1 {
+		t.Fatalf("expected no bisection, got %d requests and %d failed items", requests.Load(), len(response.Failed))
+	}
+}
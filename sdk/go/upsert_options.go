@@ -0,0 +1,105 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var ErrPointExists = errors.New("point already exists")
+
+// UpsertPointWithOptions upserts a single point. With OnlyIfAbsent the request
+// carries "If-None-Match: *" and a 409/412 answer maps to ErrPointExists. Until
+// the server has been seen enforcing that header, the client also checks for
+// the point with GetPoint first; that emulation is not atomic, so a concurrent
+// writer can still create the point between the check and the upsert.
+//
+// ReturnPrevious asks the server for the overwritten point with
+// "?return_previous=true". Until the server has been seen returning it, the
+// client reads the point with GetPoint before the upsert instead; that is not
+// atomic either, so a concurrent write in between is not reflected.
+func (c *Client) UpsertPointWithOptions(ctx context.Context, collection string, pointID uint64, values []float32, payload PointPayload, options *UpsertPointOptions, opts ...CallOption) (UpsertPointResponse, error) {
+	body := map[string]any{"values": values}
+	if payload != nil {
+		body["payload"] = payload
+	}
+	path := fmt.Sprintf(pointPathFormat, collectionPathSegment(collection), pointID)
+	onlyIfAbsent := options != nil && options.OnlyIfAbsent
+	if onlyIfAbsent {
+		if !c.capabilities.confirmed(capabilityIfNoneMatch) {
+			exists, err := c.pointExistsByGet(ctx, collection, pointID, append(opts[:len(opts):len(opts)], WithNoCache())...)
+			if err != nil {
+				return UpsertPointResponse{}, err
+			}
+			if exists {
+				return UpsertPointResponse{}, &Error{Method: http.MethodPut, Path: path, Err: ErrPointExists}
+			}
+		}
+		opts = append(opts[:len(opts):len(opts)], withRequestHeader("If-None-Match", "*"))
+	}
+	returnPrevious := options != nil && options.ReturnPrevious
+	var previous *PointResponse
+	if returnPrevious {
+		if !c.capabilities.confirmed(capabilityReturnPrevious) {
+			point, err := c.previousPointByGet(ctx, collection, pointID, opts)
+			if err != nil {
+				return UpsertPointResponse{}, err
+			}
+			previous = point
+		}
+		path += "?return_previous=true"
+	}
+
+	defer c.pointCache.invalidate(strings.TrimSpace(collection), pointID)
+	var response UpsertPointResponse
+	err := c.withAutoCreate(ctx, collection, len(values), opts, func() error {
+		return c.requestJSON(ctx, http.MethodPut, path, body, &response, opts...)
+	})
+	var requestErr *Error
+	if onlyIfAbsent && errors.As(err, &requestErr) && isConflictStatus(requestErr.Status) {
+		c.capabilities.markSupported(capabilityIfNoneMatch)
+		requestErr.Err = ErrPointExists
+	}
+	if err == nil {
+		c.recentWrites.record(strings.TrimSpace(collection), pointID, values, time.Now())
+	}
+	if err == nil && returnPrevious {
+		if response.Previous != nil {
+			c.capabilities.markSupported(capabilityReturnPrevious)
+		} else {
+			response.Previous = previous
+		}
+	}
+	return response, err
+}
+
+func (c *Client) previousPointByGet(ctx context.Context, collection string, pointID uint64, opts []CallOption) (*PointResponse, error) {
+	point, err := c.GetPoint(ctx, collection, pointID, append(opts[:len(opts):len(opts)], WithNoCache())...)
+	if err == nil {
+		return &point, nil
+	}
+	var requestErr *Error
+	if errors.As(err, &requestErr) && requestErr.Status == http.StatusNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+func (c *Client) pointExistsByGet(ctx context.Context, collection string, pointID uint64, opts ...CallOption) (bool, error) {
+	_, err := c.GetPoint(ctx, collection, pointID, opts...)
+	if err == nil {
+		return true, nil
+	}
+	var requestErr *Error
+	if errors.As(err, &requestErr) && requestErr.Status == http.StatusNotFound {
+		return false, nil
+	}
+	return false, err
+}
+
+func isConflictStatus(status int) bool {
+	return status == http.StatusConflict || status == http.StatusPreconditionFailed
+}
@@ -0,0 +1,138 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpsertPointOnlyIfAbsentMapsConflict(t *testing.T) {
+	t.Parallel()
+
+	var conditional []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.Method {
+		case http.MethodGet:
+			writer.WriteHeader(http.StatusNotFound)
+			_, _ = writer.Write([]byte(`{"error":"point not found"}`))
+		case http.MethodPut:
+			conditional = append(conditional, request.Header.Get("If-None-Match"))
+			writer.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = writer.Write([]byte(`{"error":"point exists"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	_, err := client.UpsertPointWithOptions(context.Background(), "demo", 1, []float32{1}, nil, &UpsertPointOptions{
+		OnlyIfAbsent: true,
+	})
+	if !errors.Is(err, ErrPointExists) {
+		t.Fatalf("expected ErrPointExists, got %v", err)
+	}
+	var requestErr *Error
+	if !errors.As(err, &requestErr) || requestErr.Status != http.StatusPreconditionFailed {
+		t.Fatalf("expected *Error with status 412, got %#v", err)
+	}
+	if len(conditional) != 1 || conditional[0] != "*" {
+		t.Fatalf("expected If-None-Match: *, got %v", conditional)
+	}
+	if !client.capabilities.confirmed(capabilityIfNoneMatch) {
+		t.Fatal("expected server enforcement to be remembered")
+	}
+}
+
+func TestUpsertPointOnlyIfAbsentEmulatesWithPrecheck(t *testing.T) {
+	t.Parallel()
+
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("X-Tenant") != "a" {
+			http.Error(writer, "unknown tenant", http.StatusUnauthorized)
+			return
+		}
+		if request.Method == http.MethodPut {
+			puts++
+		}
+		writeJSON(t, writer, map[string]any{"id": 1, "values": []float32{1}, "payload": map[string]any{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	_, err := client.UpsertPointWithOptions(context.Background(), "demo", 1, []float32{1}, nil, &UpsertPointOptions{
+		OnlyIfAbsent: true,
+	}, WithHeader("X-Tenant", "a"))
+	if !errors.Is(err, ErrPointExists) {
+		t.Fatalf("expected ErrPointExists from precheck, got %v", err)
+	}
+	if puts != 0 {
+		t.Fatalf("expected no upsert after a positive precheck, got %d", puts)
+	}
+}
+
+func TestUpsertPointReturnPreviousEmulatesWithGet(t *testing.T) {
+	t.Parallel()
+
+	stored := map[string]any{"id": 1, "values": []float32{0.5, 0.5}, "payload": map[string]any{"rev": "1"}}
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("X-Tenant") != "a" {
+			http.Error(writer, "unknown tenant", http.StatusUnauthorized)
+			return
+		}
+		switch request.Method {
+		case http.MethodGet:
+			writeJSON(t, writer, stored)
+		case http.MethodPut:
+			queries = append(queries, request.URL.RawQuery)
+			writeJSON(t, writer, map[string]any{"id": 1, "created": false})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	response, err := client.UpsertPointWithOptions(context.Background(), "demo", 1, []float32{1, 1}, PointPayload{"rev": "2"}, &UpsertPointOptions{
+		ReturnPrevious: true,
+	}, WithHeader("X-Tenant", "a"))
+	if err != nil {
+		t.Fatalf("UpsertPointWithOptions failed: %v", err)
+	}
+	if response.Previous == nil || response.Previous.Values[0] != 0.5 || response.Previous.Payload["rev"] != "1" {
+		t.Fatalf("expected the overwritten point, got %+v", response.Previous)
+	}
+	if len(queries) != 1 || queries[0] != "return_previous=true" {
+		t.Fatalf("expected return_previous to be requested, got %v", queries)
+	}
+}
+
+func TestUpsertPointReturnPreviousUsesServerResponse(t *testing.T) {
+	t.Parallel()
+
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodGet {
+			gets++
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(t, writer, map[string]any{"id": 1, "created": false, "previous": map[string]any{"id": 1, "values": []float32{2}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	options := &UpsertPointOptions{ReturnPrevious: true}
+	for range 2 {
+		response, err := client.UpsertPointWithOptions(context.Background(), "demo", 1, []float32{3}, nil, options)
+		if err != nil {
+			t.Fatalf("UpsertPointWithOptions failed: %v", err)
+		}
+		if response.Previous == nil || response.Previous.Values[0] != 2 {
+			t.Fatalf("expected the server's previous point, got %+v", response.Previous)
+		}
+	}
+	if gets != 1 {
+		t.Fatalf("expected the GET fallback to stop once the server returned previous, got %d GETs", gets)
+	}
+}
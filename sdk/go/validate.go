@@ -0,0 +1,58 @@
+package aionbd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+var (
+	ErrDNSFailure        = errors.New("server host could not be resolved")
+	ErrConnectionRefused = errors.New("server refused the connection")
+	ErrUnauthorized      = errors.New("server rejected the credentials")
+	ErrUnexpectedService = errors.New("unexpected service: response is not from an AIONBD server")
+)
+
+// Validate checks the client configuration with one GET /live and reports the
+// likely cause of a failure: ErrDNSFailure, ErrConnectionRefused,
+// ErrUnauthorized, or ErrUnexpectedService when something other than AIONBD
+// answered. Other failures, such as timeouts or 5xx responses, are returned
+// as is. It is never called automatically.
+func (c *Client) Validate(ctx context.Context, opts ...CallOption) error {
+	payload, err := c.doRequest(ctx, http.MethodGet, "/live", nil, false, newCallConfig(opts))
+	if err != nil {
+		return classifyValidateError(err)
+	}
+	var live map[string]json.RawMessage
+	if json.Unmarshal(payload, &live) != nil || !bytes.Equal(live["status"], []byte(`"live"`)) || live["uptime_ms"] == nil {
+		return &Error{Method: http.MethodGet, Path: "/live", Body: string(payload), Err: ErrUnexpectedService}
+	}
+	return nil
+}
+
+func classifyValidateError(err error) error {
+	var requestErr *Error
+	if !errors.As(err, &requestErr) {
+		return err
+	}
+	var dnsErr *net.DNSError
+	switch {
+	case requestErr.Status == http.StatusUnauthorized || requestErr.Status == http.StatusForbidden:
+		requestErr.Err = ErrUnauthorized
+	case requestErr.Status == http.StatusNotFound || requestErr.Status == http.StatusMethodNotAllowed:
+		requestErr.Err = ErrUnexpectedService
+	case requestErr.Status == 0 && requestErr.Body != "":
+		// The body was received but is not JSON.
+		requestErr.Err = fmt.Errorf("%w: %w", ErrUnexpectedService, requestErr.Err)
+	case errors.As(err, &dnsErr):
+		requestErr.Err = fmt.Errorf("%w: %w", ErrDNSFailure, requestErr.Err)
+	case errors.Is(err, syscall.ECONNREFUSED):
+		requestErr.Err = fmt.Errorf("%w: %w", ErrConnectionRefused, requestErr.Err)
+	}
+	return requestErr
+}
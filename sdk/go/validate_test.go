@@ -0,0 +1,65 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateClassifiesFailures(t *testing.T) {
+	t.Parallel()
+
+	handlers := map[string]http.HandlerFunc{
+		"aionbd": func(writer http.ResponseWriter, request *http.Request) {
+			writeJSON(t, writer, map[string]any{"status": "live", "uptime_ms": 42})
+		},
+		"other json": func(writer http.ResponseWriter, request *http.Request) {
+			writeJSON(t, writer, map[string]any{"hello": "world"})
+		},
+		"html page": func(writer http.ResponseWriter, request *http.Request) {
+			writer.Header().Set("Content-Type", "text/html")
+			_, _ = writer.Write([]byte("<html><body>It works!</body></html>"))
+		},
+		"auth": func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusUnauthorized)
+		},
+	}
+	cases := []struct {
+		name string
+		want error
+	}{
+		{"aionbd", nil},
+		{"other json", ErrUnexpectedService},
+		{"html page", ErrUnexpectedService},
+		{"auth", ErrUnauthorized},
+	}
+	for _, tc := range cases {
+		server := httptest.NewServer(handlers[tc.name])
+		err := NewClient(server.URL, nil).Validate(context.Background())
+		server.Close()
+		if tc.want == nil {
+			if err != nil {
+				t.Fatalf("%s: expected success, got %v", tc.name, err)
+			}
+			continue
+		}
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestValidateReportsConnectionRefused(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	baseURL := server.URL
+	server.Close()
+
+	err := NewClient(baseURL, nil).Validate(context.Background())
+	if !errors.Is(err, ErrConnectionRefused) {
+		t.Fatalf("expected ErrConnectionRefused, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package aionbd
+
+import (
+	"fmt"
+	"math"
+)
+
+// VectorError describes one problem with the vector of a batch item.
+type VectorError struct {
+	Index   int
+	ID      uint64
+	Problem string
+}
+
+func (e VectorError) Error() string {
+	return fmt.Sprintf("items[%d] (id %d): %s", e.Index, e.ID, e.Problem)
+}
+
+// ValidateVectors checks every item before an ingest and returns all problems
+// found, in item order, instead of stopping at the first: empty vectors,
+// lengths other than dim (skipped when dim is not positive), and, with
+// strictFinite, NaN or infinite values (the first one of each item). A nil
+// result means the batch is valid.
+func ValidateVectors(dim int, items []UpsertPointsBatchItem, strictFinite bool) []VectorError {
+	var problems []VectorError
+	for index, item := range items {
+		report := func(format string, args ...any) {
+			problems = append(problems, VectorError{Index: index, ID: item.ID, Problem: fmt.Sprintf(format, args...)})
+		}
+		if len(item.Values) == 0 {
+			report("vector is empty")
+			continue
+		}
+		if dim > 0 && len(item.Values) != dim {
+			report("vector has %d values, expected %d", len(item.Values), dim)
+		}
+		if !strictFinite {
+			continue
+		}
+		for position, value := range item.Values {
+			if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+				report("values[%d] is not finite (%v)", position, value)
+				break
+			}
+		}
+	}
+	return problems
+}
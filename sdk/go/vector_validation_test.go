@@ -0,0 +1,44 @@
+package aionbd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestValidateVectorsReportsEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	nan := float32(math.NaN())
+	inf := float32(math.Inf(1))
+	items := []UpsertPointsBatchItem{
+		{ID: 10, Values: []float32{1, 2, 3}},
+		{ID: 11, Values: []float32{1, 2}},
+		{ID: 12, Values: nil},
+		{ID: 13, Values: []float32{1, nan, inf}},
+		{ID: 14, Values: []float32{inf, 1}},
+	}
+
+	problems := ValidateVectors(3, items, true)
+	want := []VectorError{
+		{Index: 1, ID: 11, Problem: "vector has 2 values, expected 3"},
+		{Index: 2, ID: 12, Problem: "vector is empty"},
+		{Index: 3, ID: 13, Problem: "values[1] is not finite (NaN)"},
+		{Index: 4, ID: 14, Problem: "vector has 2 values, expected 3"},
+		{Index: 4, ID: 14, Problem: "values[0] is not finite (+Inf)"},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("expected %d problems, got %v", len(want), problems)
+	}
+	for index := range want {
+		if problems[index] != want[index] {
+			t.Fatalf("problem %d: expected %+v, got %+v", index, want[index], problems[index])
+		}
+	}
+	if got := problems[0].Error(); got != "items[1] (id 11): vector has 2 values, expected 3" {
+		t.Fatalf("unexpected error text %q", got)
+	}
+
+	if problems := ValidateVectors(3, items, false); len(problems) != 3 {
+		t.Fatalf("expected non-finite values to be allowed without strictFinite, got %v", problems)
+	}
+}
@@ -0,0 +1,141 @@
+package aionbd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	changesPathFormat = "/collections/%s/changes"
+
+	watchInitialBackoff = 100 * time.Millisecond
+	watchMaxBackoff     = 5 * time.Second
+)
+
+var errInvalidChangeEvent = errors.New("invalid change event")
+
+type ChangeEventType string
+
+const (
+	ChangeUpsert ChangeEventType = "upsert"
+	ChangeDelete ChangeEventType = "delete"
+)
+
+type ChangeEvent struct {
+	Type      ChangeEventType `json:"type"`
+	PointID   uint64          `json:"point_id"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// WatchCollection streams the collection's change feed (NDJSON, or SSE "data:"
+// lines) from /collections/{name}/changes and calls fn for every event until
+// ctx is canceled or fn returns an error, which is returned as is. Dropped
+// streams are reopened with ?since= set to the last delivered timestamp:
+// right away after a stream that delivered events, otherwise with a backoff
+// from 100ms up to 5s. 4xx answers other than 408 and 429 end the watch, as
+// does a malformed event; a server without a change feed reports its 404. The
+// HTTP client timeout also bounds each stream, which then simply reconnects.
+func (c *Client) WatchCollection(ctx context.Context, collection string, fn func(ChangeEvent) error, opts ...CallOption) error {
+	if fn == nil {
+		return fmt.Errorf("fn must not be nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	basePath := fmt.Sprintf(changesPathFormat, collectionPathSegment(collection))
+	if c.configErr != nil {
+		return &Error{Method: http.MethodGet, Path: basePath, Err: fmt.Errorf("invalid client configuration: %w", c.configErr)}
+	}
+	call := newCallConfig(opts)
+
+	var since time.Time
+	var fnErr error
+	handle := func(event ChangeEvent) bool {
+		if fnErr = fn(event); fnErr != nil {
+			return false
+		}
+		if event.Timestamp.After(since) {
+			since = event.Timestamp
+		}
+		return true
+	}
+
+	backoff := watchInitialBackoff
+	for {
+		path := basePath
+		if !since.IsZero() {
+			path += "?since=" + url.QueryEscape(since.Format(time.RFC3339Nano))
+		}
+		delivered, err := c.readChangeStream(ctx, path, call, handle)
+		if fnErr != nil {
+			return fnErr
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && isFatalChangeStreamError(err) {
+			return err
+		}
+		if delivered > 0 {
+			backoff = watchInitialBackoff
+			continue
+		}
+		if err := sleepContext(ctx, backoff); err != nil {
+			return err
+		}
+		backoff = min(backoff*2, watchMaxBackoff)
+	}
+}
+
+// readChangeStream delivers the events of one stream to handle until the
+// stream ends or handle returns false, reporting how many were delivered.
+func (c *Client) readChangeStream(ctx context.Context, path string, call *callConfig, handle func(ChangeEvent) bool) (int, error) {
+	body, err := c.openStream(ctx, http.MethodGet, path, nil, "application/x-ndjson, text/event-stream", call)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	delivered := 0
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if data, isData := bytes.CutPrefix(line, []byte("data:")); isData {
+			line = bytes.TrimSpace(data)
+		} else if len(line) == 0 || line[0] != '{' {
+			// Blank keep-alives and other SSE fields carry no event.
+			continue
+		}
+		var event ChangeEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return delivered, &Error{Method: http.MethodGet, Path: path, Body: string(line), Err: fmt.Errorf("%w: %v", errInvalidChangeEvent, err)}
+		}
+		delivered++
+		if !handle(event) {
+			return delivered, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return delivered, &Error{Method: http.MethodGet, Path: path, Err: err}
+	}
+	return delivered, nil
+}
+
+func isFatalChangeStreamError(err error) bool {
+	if errors.Is(err, errInvalidChangeEvent) {
+		return true
+	}
+	var requestErr *Error
+	if !errors.As(err, &requestErr) {
+		return false
+	}
+	return requestErr.Status >= 400 && requestErr.Status < 500 &&
+		requestErr.Status != http.StatusRequestTimeout && requestErr.Status != http.StatusTooManyRequests
+}
@@ -0,0 +1,84 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatchCollectionDeliversEventsAndReconnectsAfterDrop(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	var mu sync.Mutex
+	var sinces []string
+	connections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/collections/demo/changes" {
+			t.Errorf("unexpected path %q", request.URL.Path)
+		}
+		mu.Lock()
+		connections++
+		connection := connections
+		sinces = append(sinces, request.URL.Query().Get("since"))
+		mu.Unlock()
+
+		writer.Header().Set("Content-Type", "application/x-ndjson")
+		switch connection {
+		case 1:
+			fmt.Fprintf(writer, "{\"type\":\"upsert\",\"point_id\":1,\"timestamp\":%q}\n\n", base.Format(time.RFC3339Nano))
+			fmt.Fprintf(writer, "{\"type\":\"delete\",\"point_id\":2,\"timestamp\":%q}\n", base.Add(time.Second).Format(time.RFC3339Nano))
+			// Returning drops the stream.
+		case 2:
+			http.Error(writer, "unavailable", http.StatusServiceUnavailable)
+		default:
+			fmt.Fprintf(writer, "data: {\"type\":\"upsert\",\"point_id\":3,\"timestamp\":%q}\n", base.Add(2*time.Second).Format(time.RFC3339Nano))
+		}
+	}))
+	defer server.Close()
+
+	stop := errors.New("stop")
+	var events []ChangeEvent
+	client := NewClient(server.URL, nil)
+	err := client.WatchCollection(context.Background(), "demo", func(event ChangeEvent) error {
+		events = append(events, event)
+		if len(events) == 3 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected the callback error, got %v", err)
+	}
+
+	if len(events) != 3 || events[0].Type != ChangeUpsert || events[1].Type != ChangeDelete || events[2].PointID != 3 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	if !events[1].Timestamp.Equal(base.Add(time.Second)) {
+		t.Fatalf("unexpected timestamp: %v", events[1].Timestamp)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	resumeFrom := base.Add(time.Second).Format(time.RFC3339Nano)
+	if len(sinces) != 3 || sinces[0] != "" || sinces[1] != resumeFrom || sinces[2] != resumeFrom {
+		t.Fatalf("unexpected since parameters: %q", sinces)
+	}
+}
+
+func TestWatchCollectionStopsOnMissingFeed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	err := client.WatchCollection(context.Background(), "demo", func(ChangeEvent) error { return nil })
+	if !isStatus(err, http.StatusNotFound) {
+		t.Fatalf("expected a 404 error, got %v", err)
+	}
+}
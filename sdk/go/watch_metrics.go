@@ -0,0 +1,78 @@
+package aionbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type MetricsSample struct {
+	Time    time.Time
+	Metrics MetricsResponse
+}
+
+// WatchMetrics polls /metrics every interval, starting immediately, and emits
+// each sample until ctx is canceled; both channels are then closed. Failed
+// polls are sent on the error channel and the watch keeps going, except for
+// fatal errors (invalid client configuration, 401, 403) which end it. Slow
+// receivers delay the next poll rather than dropping samples.
+func (c *Client) WatchMetrics(ctx context.Context, interval time.Duration) (<-chan MetricsSample, <-chan error) {
+	samples := make(chan MetricsSample)
+	errs := make(chan error, 1)
+	if interval <= 0 {
+		errs <- fmt.Errorf("interval must be positive")
+		close(samples)
+		close(errs)
+		return samples, errs
+	}
+
+	go func() {
+		defer close(samples)
+		defer close(errs)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			metrics, err := c.Metrics(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					return
+				}
+				if c.isFatalWatchError(err) {
+					return
+				}
+			} else {
+				select {
+				case samples <- MetricsSample{Time: time.Now(), Metrics: metrics}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return samples, errs
+}
+
+func (c *Client) isFatalWatchError(err error) bool {
+	if c.configErr != nil {
+		return true
+	}
+	var requestErr *Error
+	if !errors.As(err, &requestErr) {
+		return false
+	}
+	return requestErr.Status == http.StatusUnauthorized || requestErr.Status == http.StatusForbidden
+}
@@ -0,0 +1,65 @@
+package aionbd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchMetricsEmitsSamplesUntilCanceled(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		count := polls.Add(1)
+		if count == 2 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			writeJSON(t, writer, map[string]any{"error": "busy"})
+			return
+		}
+		writeJSON(t, writer, map[string]any{"uptime_ms": count, "ready": true})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interval := 20 * time.Millisecond
+	samples, errs := client.WatchMetrics(ctx, interval)
+
+	first := <-samples
+	if first.Metrics.UptimeMS != 1 {
+		t.Fatalf("unexpected first sample: %#v", first.Metrics)
+	}
+	if err := <-errs; err == nil {
+		t.Fatal("expected the failed poll to be reported")
+	}
+	second := <-samples
+	if second.Metrics.UptimeMS != 3 {
+		t.Fatalf("expected the watch to continue after an error, got: %#v", second.Metrics)
+	}
+	if gap := second.Time.Sub(first.Time); gap < 2*interval-5*time.Millisecond || gap > 2*interval+200*time.Millisecond {
+		t.Fatalf("unexpected sample cadence: %s", gap)
+	}
+
+	cancel()
+	deadline := time.After(time.Second)
+	for samples != nil || errs != nil {
+		select {
+		case _, ok := <-samples:
+			if !ok {
+				samples = nil
+			}
+		case _, ok := <-errs:
+			if !ok {
+				errs = nil
+			}
+		case <-deadline:
+			t.Fatal("channels were not closed after cancel")
+		}
+	}
+}